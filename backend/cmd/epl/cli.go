@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+)
+
+// cli carries the settings shared by every command and counts failures
+type cli struct {
+	out, errOut io.Writer
+	dryRun      bool
+	dataDir     string
+	timeout     time.Duration
+
+	cfg      *config.Config
+	failures int
+}
+
+func newCLI(out, errOut io.Writer) *cli {
+	return &cli{out: out, errOut: errOut}
+}
+
+func (c *cli) registerGlobal(fs *flag.FlagSet) {
+	fs.BoolVar(&c.dryRun, "dry-run", false, "show what would change without writing to the database")
+	fs.StringVar(&c.dataDir, "data-dir", "", "directory holding results.json, ptext.txt, ... (default: search upwards from the working directory)")
+	fs.DurationVar(&c.timeout, "timeout", 10*time.Minute, "give up after this long")
+}
+
+// flags returns the flag set of a command. The global flags are accepted after the
+// command name too, so "epl seed stats --dry-run" works.
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("epl "+name, flag.ContinueOnError)
+	fs.SetOutput(c.errOut)
+	c.registerGlobal(fs)
+	return fs
+}
+
+// parse parses a command's flags, reporting stray arguments as a usage error
+func (c *cli) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, fs.Arg(0))
+	}
+	return nil
+}
+
+// connect loads the configuration and connects to MongoDB, once
+func (c *cli) connect() *config.Config {
+	if c.cfg == nil {
+		c.cfg = config.LoadConfig()
+		database.ConnectDB(c.cfg)
+		if c.dryRun {
+			c.step("Dry run: nothing will be written")
+		}
+	}
+	return c.cfg
+}
+
+func (c *cli) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+// dataFile finds one of the repository's data files: in --data-dir if given,
+// otherwise in the working directory or the nearest parent that has it
+func (c *cli) dataFile(name string) (string, error) {
+	if c.dataDir != "" {
+		path := filepath.Join(c.dataDir, name)
+		if _, err := os.Stat(path); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%s not found in the working directory or its parents (use --data-dir)", name)
+		}
+		dir = parent
+	}
+}
+
+// step starts a new stage of the command
+func (c *cli) step(format string, args ...interface{}) {
+	fmt.Fprintf(c.out, "==> "+format+"\n", args...)
+}
+
+// info reports progress within a stage
+func (c *cli) info(format string, args ...interface{}) {
+	fmt.Fprintf(c.out, "    "+format+"\n", args...)
+}
+
+// progress reports item i of n
+func (c *cli) progress(i, n int, format string, args ...interface{}) {
+	width := len(fmt.Sprint(n))
+	fmt.Fprintf(c.out, "    [%*d/%d] %s\n", width, i, n, fmt.Sprintf(format, args...))
+}
+
+// warn reports something odd that doesn't make the run fail
+func (c *cli) warn(format string, args ...interface{}) {
+	fmt.Fprintf(c.errOut, "    ⚠ "+format+"\n", args...)
+}
+
+// fail reports a failed item; the command carries on but exits non-zero
+func (c *cli) fail(format string, args ...interface{}) {
+	c.failures++
+	fmt.Fprintf(c.errOut, "    ✗ "+format+"\n", args...)
+}
+
+// finish prints the outcome and returns the exit status
+func (c *cli) finish() int {
+	if c.failures > 0 {
+		fmt.Fprintf(c.errOut, "❌ Finished with %d failure(s)\n", c.failures)
+		return 1
+	}
+	if c.dryRun {
+		fmt.Fprintln(c.out, "✅ Dry run complete, nothing was written")
+	} else {
+		fmt.Fprintln(c.out, "✅ Done")
+	}
+	return 0
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// debugLatest lists the latest finished matches, by matchday then date
+func debugLatest(c *cli, args []string) error {
+	fs := c.flags("debug latest")
+	limit := fs.Int64("n", 10, "number of matches to show")
+	season := fs.String("season", "", "season to look at (default: every season)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	filter := bson.M{"status": models.MatchFinished}
+	if *season != "" {
+		seasonID, err := services.ResolveSeasonID(*season)
+		if err != nil {
+			return err
+		}
+		filter["seasonId"] = seasonID
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+	opts := options.Find().
+		SetSort(bson.D{
+			{Key: "matchday", Value: -1},
+			{Key: "date", Value: -1},
+		}).
+		SetLimit(*limit)
+	cursor, err := database.DB.Collection("matches").Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return err
+	}
+
+	c.step("Latest %d finished matches (by matchday DESC, date DESC)", len(matches))
+	for _, m := range matches {
+		c.info("%s | Matchday %d | %s v %s | %d:%d | %s",
+			m.SeasonID, m.Matchday, m.HomeTeamID, m.AwayTeamID,
+			m.HomeScore, m.AwayScore, m.Date.Format("Mon 02 Jan 2006"))
+	}
+	return nil
+}
+
+// debugDB prints the teams, a season's table and the size of every other collection
+func debugDB(c *cli, args []string) error {
+	fs := c.flags("debug db")
+	season := fs.String("season", "", "season of the table (default: the active season)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	var teams []models.Team
+	if err := findAll(ctx, "teams", &teams); err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+	c.step("Teams (total: %d)", len(teams))
+	for i, team := range teams {
+		if i < 10 { // Show first 10
+			c.info("%d. %s (%s) - Logo: %s", i+1, team.Name, team.ShortName, team.LogoURL)
+		}
+	}
+
+	seasonID, err := services.ResolveSeasonID(*season)
+	if err != nil {
+		return err
+	}
+	c.step("%s standings", seasonID)
+	if err := c.printTable(seasonID); err != nil {
+		return err
+	}
+
+	c.step("Other collections")
+	for _, name := range []string{"users", "players", "matches", "seasons", "goal_events", "coaches", "goalscorers", "assists", "cleansheets"} {
+		n, err := database.DB.Collection(name).CountDocuments(ctx, bson.M{})
+		if err != nil {
+			c.fail("Count %s: %v", name, err)
+			continue
+		}
+		c.info("%-12s %d", name+":", n)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+)
+
+// importSportMonks imports squads, standings and fixtures from the SportMonks API.
+// With --mode record every response is also saved under --fixtures, and with
+// --mode replay the import runs from those files alone, without a token or network.
+func importSportMonks(c *cli, args []string) error {
+	fs := c.flags("import")
+	what := fs.String("what", "standings,squads,fixtures", "what to import: any of standings, squads, fixtures")
+	season := fs.String("season", "", "our season to import into (default: the active season)")
+	smSeason := fs.String("sm-season", "", "SportMonks season ID (default: SPORTMONKS_SEASON_ID)")
+	mode := fs.String("mode", "", "live, record or replay (default: SPORTMONKS_MODE)")
+	fixtures := fs.String("fixtures", "", "directory of recorded responses (default: SPORTMONKS_FIXTURES_DIR)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+
+	steps := map[string]bool{}
+	for _, s := range strings.Split(*what, ",") {
+		s = strings.TrimSpace(s)
+		if s != "standings" && s != "squads" && s != "fixtures" {
+			return fmt.Errorf("%w: unknown import %q, use standings, squads or fixtures", errUsage, s)
+		}
+		steps[s] = true
+	}
+
+	cfg := c.connect()
+	if *smSeason == "" {
+		*smSeason = cfg.SportMonksSeasonID
+	}
+	if *mode != "" {
+		cfg.SportMonksMode = *mode
+	}
+	if *fixtures != "" {
+		cfg.SportMonksFixturesDir = *fixtures
+	}
+	client, err := services.NewSportMonksClient(cfg)
+	if err != nil {
+		return err
+	}
+	seasonID, err := services.ResolveSeasonID(*season)
+	if err != nil {
+		return err
+	}
+
+	teams, err := repositories.NewTeamRepository().GetAllTeams()
+	if err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+	importer := services.NewSportMonksImporter(client, teams)
+	importer.DryRun = c.dryRun
+	defer c.reportUnmatched(importer.Resolver())
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	// Standings first: they create any club we don't have yet. A failed step doesn't
+	// stop the others, but makes the run fail.
+	for _, step := range []string{"standings", "squads", "fixtures"} {
+		if !steps[step] {
+			continue
+		}
+		c.step("Importing %s (SportMonks season %s into %s, %s mode)", step, *smSeason, seasonID, cfg.SportMonksMode)
+		var res services.ImportResult
+		switch step {
+		case "standings":
+			res, err = importer.ImportStandings(ctx, *smSeason, seasonID)
+		case "squads":
+			res, err = importer.ImportSquads(ctx, *smSeason)
+		case "fixtures":
+			res, err = importer.ImportFixtures(ctx, *smSeason, seasonID)
+		}
+		if err != nil {
+			c.fail("Import %s: %v", step, err)
+			continue
+		}
+		c.info("%s: %s", step, res)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexes lists the indexes of every collection the API queries by more than _id
+var indexes = []struct {
+	collection string
+	models     []mongo.IndexModel
+}{
+	{"goal_events", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "scorerId", Value: 1}}},
+		{Keys: bson.D{{Key: "assistId", Value: 1}}},
+		{Keys: bson.D{{Key: "matchIndex", Value: 1}}},
+		{Keys: bson.D{{Key: "minute", Value: 1}}},
+		{Keys: bson.D{{Key: "seasonId", Value: 1}}},
+	}},
+	{"players", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "teamId", Value: 1}}},
+		{Keys: bson.D{{Key: "position", Value: 1}}},
+		{Keys: bson.D{{Key: "name", Value: 1}}}, // Sorting and searching
+	}},
+	{"matches", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "date", Value: 1}}},
+		{Keys: bson.D{{Key: "homeTeamId", Value: 1}}},
+		{Keys: bson.D{{Key: "awayTeamId", Value: 1}}},
+		{Keys: bson.D{{Key: "seasonId", Value: 1}, {Key: "matchday", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "date", Value: 1}}}, // Kickoff scheduler scans
+	}},
+	{"api_keys", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "keyHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	}},
+	{"audit_log", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "actorId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "actorEmail", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "targetType", Value: 1}, {Key: "targetId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	}},
+	{"email_tokens", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tokenHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "purpose", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // Expired links are deleted
+	}},
+	{"invites", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tokenHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}},
+	{"login_attempts", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "lastFailureAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60)}, // Old failures are forgotten
+	}},
+	{"rate_limits", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // Finished windows are deleted
+	}},
+	{"oidc_states", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // Abandoned sign-ins are deleted
+	}},
+	{"refresh_tokens", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tokenHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "familyId", Value: 1}}},
+		{Keys: bson.D{{Key: "userId", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // Expired tokens are deleted
+	}},
+	{"role_changes", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "createdAt", Value: -1}}},
+	}},
+	{"users", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "identities.issuer", Value: 1}, {Key: "identities.subject", Value: 1}}}, // Single sign-on
+	}},
+	// Season-scoped collections
+	{"standings", []mongo.IndexModel{{Keys: bson.D{{Key: "seasonId", Value: 1}}}}},
+	{"player_season_stats", []mongo.IndexModel{{Keys: bson.D{{Key: "seasonId", Value: 1}}}}},
+	{"goalscorers", []mongo.IndexModel{{Keys: bson.D{{Key: "seasonId", Value: 1}}}}},
+	{"assists", []mongo.IndexModel{{Keys: bson.D{{Key: "seasonId", Value: 1}}}}},
+	{"cleansheets", []mongo.IndexModel{{Keys: bson.D{{Key: "seasonId", Value: 1}}}}},
+}
+
+// ensureIndexes creates any missing index; existing ones are left as they are
+func ensureIndexes(c *cli, args []string) error {
+	fs := c.flags("indexes ensure")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Ensuring indexes")
+	ctx, cancel := c.context()
+	defer cancel()
+	for i, idx := range indexes {
+		keys := make([]string, len(idx.models))
+		for j, m := range idx.models {
+			keys[j] = indexName(m.Keys.(bson.D))
+		}
+		c.progress(i+1, len(indexes), "%s: %s", idx.collection, strings.Join(keys, ", "))
+		if c.dryRun {
+			continue
+		}
+		if _, err := database.DB.Collection(idx.collection).Indexes().CreateMany(ctx, idx.models); err != nil {
+			c.fail("Create %s indexes: %v", idx.collection, err)
+		}
+	}
+	return nil
+}
+
+// indexName formats index keys the way MongoDB names the index, e.g. "seasonId_1_matchday_1"
+func indexName(keys bson.D) string {
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", k.Key, k.Value))
+	}
+	return strings.Join(parts, "_")
+}
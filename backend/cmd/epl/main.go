@@ -0,0 +1,125 @@
+// Command epl is the admin CLI for the league database: seeding, imports,
+// recalculations, indexes, debugging, user management and a mock OIDC provider.
+//
+//	epl [--env-file FILE] [--data-dir DIR] [--dry-run] <command> [<subcommand>] [flags]
+//
+// Every command reports its progress as it goes and exits with status 1 if anything
+// failed, including a single item of an otherwise successful run, and 2 on bad usage.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// command is one "epl <name>" or "epl <group> <name>" subcommand
+type command struct {
+	name    string // "seed teams", "debug latest", ...
+	summary string
+	run     func(c *cli, args []string) error
+}
+
+var commands = []command{
+	{"seed all", "every seed step in order, then recalc stats", seedAll},
+	{"seed teams", "teams and standings from the SportMonks table in ptext.txt", seedTeams},
+	{"seed aliases", "team name aliases and SportMonks IDs", seedAliases},
+	{"seed matches", "fixtures and results from openfootball or results.json", seedMatches},
+	{"seed squad", "one team's players from a SportMonks squad file", seedSquad},
+	{"seed stats", "goal events and player statistics generated from results.json", seedStats},
+	{"seed coaches", "the default head coaches", seedCoaches},
+	{"seed fixtures", "a generated double round-robin for a season", seedFixtures},
+	{"import", "squads, standings and fixtures from the SportMonks API", importSportMonks},
+	{"recalc standings", "rebuild a season's standings from its finished matches", recalcStandings},
+	{"recalc stats", "rebuild the coaches and leaderboard collections", recalcStats},
+	{"indexes ensure", "create every index the API relies on", ensureIndexes},
+	{"migrate seasons", "move a single-season database to the season-keyed layout", migrateSeasons},
+	{"migrate verified", "mark accounts made before email verification as verified", migrateVerified},
+	{"migrate admins", "promote ADMINs made before SUPERADMIN existed, so they keep users:manage", migrateAdmins},
+	{"debug latest", "the latest finished matches", debugLatest},
+	{"debug db", "collection counts and the current table", debugDB},
+	{"user create-admin", "create a staff user, or promote an existing one", createAdmin},
+	{"oidc mock", "serve a mock OIDC provider for local single sign-on", oidcMock},
+}
+
+// errUsage marks an error in how the command was called
+var errUsage = errors.New("usage")
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	c := newCLI(os.Stdout, os.Stderr)
+
+	global := flag.NewFlagSet("epl", flag.ContinueOnError)
+	global.SetOutput(os.Stderr)
+	envFile := global.String("env-file", "", "load settings from this file before .env and the environment")
+	c.registerGlobal(global)
+	global.Usage = func() { usage(global) }
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *envFile != "" {
+		if err := godotenv.Load(*envFile); err != nil {
+			fmt.Fprintf(os.Stderr, "epl: %v\n", err)
+			return 2
+		}
+	}
+
+	cmd, rest := findCommand(global.Args())
+	if cmd == nil {
+		usage(global)
+		return 2
+	}
+
+	err := cmd.run(c, rest)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "epl %s: %v\n", cmd.name, err)
+		return 2
+	case err != nil:
+		c.fail("%v", err)
+	}
+	return c.finish()
+}
+
+// findCommand matches the longest command name at the start of args
+func findCommand(args []string) (*command, []string) {
+	for n := 2; n >= 1; n-- {
+		if len(args) < n {
+			continue
+		}
+		name := strings.Join(args[:n], " ")
+		for i := range commands {
+			if commands[i].name == name {
+				return &commands[i], args[n:]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func usage(global *flag.FlagSet) {
+	fmt.Fprintln(os.Stderr, "Usage: epl [flags] <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	sorted := append([]command(nil), commands...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	for _, cmd := range sorted {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nFlags:")
+	global.PrintDefaults()
+	fmt.Fprintln(os.Stderr, "\nRun 'epl <command> -h' for the flags of a command.")
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// noSeason matches documents written before seasons were tracked
+var noSeason = bson.M{"$or": bson.A{
+	bson.M{"seasonId": bson.M{"$exists": false}},
+	bson.M{"seasonId": ""},
+}}
+
+// migrateSeasons moves a single-season database to the season-keyed layout:
+// matches, goal events, match events and suspensions get a canonical seasonId, standings and the leaderboard
+// collections are re-keyed to "<season>:<id>", and player statistics are copied into
+// player_season_stats. It is safe to run more than once.
+func migrateSeasons(c *cli, args []string) error {
+	fs := c.flags("migrate seasons")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Migrating to season-keyed collections")
+	ctx, cancel := c.context()
+	defer cancel()
+	seasonID := services.DefaultSeasonID
+
+	// 1. Matches: "2025/26" and missing season IDs become "2025-26"
+	matchColl := database.DB.Collection("matches")
+	legacy := bson.M{"seasonId": bson.M{"$in": bson.A{"2025/26", "", nil}}}
+	if c.dryRun {
+		n, err := matchColl.CountDocuments(ctx, legacy)
+		if err != nil {
+			return fmt.Errorf("count matches: %w", err)
+		}
+		c.info("Matches:      %d would move to %s", n, seasonID)
+	} else {
+		res, err := matchColl.UpdateMany(ctx, legacy, bson.M{"$set": bson.M{"seasonId": seasonID}})
+		if err != nil {
+			return fmt.Errorf("update matches: %w", err)
+		}
+		c.info("Matches:      %d moved to %s", res.ModifiedCount, seasonID)
+	}
+
+	// 2. Season document, active, with the clubs that appear in its fixtures
+	cursor, err := matchColl.Find(ctx, bson.M{"seasonId": seasonID})
+	if err != nil {
+		return fmt.Errorf("fetch matches: %w", err)
+	}
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return fmt.Errorf("decode matches: %w", err)
+	}
+	if err := c.upsertSeason(ctx, seasonID, matches); err != nil {
+		c.fail("Save season: %v", err)
+	}
+
+	// 3. Goal events, cards, shots, substitutions and suspensions take the season of their match
+	matchSeason, err := c.matchSeasons(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch match seasons: %w", err)
+	}
+	for _, coll := range []struct{ name, label, matchField string }{
+		{"goal_events", "Goal events:", "matchId"},
+		{"match_events", "Match events:", "matchId"},
+		{"suspensions", "Suspensions:", "triggerMatchId"},
+	} {
+		if err := c.stampSeason(ctx, coll.name, coll.label, coll.matchField, seasonID, matchSeason); err != nil {
+			c.fail("Update %s: %v", coll.name, err)
+		}
+	}
+
+	// 4. Standings keyed by team ID become "<season>:<teamId>"
+	// 5. Leaderboards keyed by player ID become "<season>:<playerId>"
+	for _, coll := range []struct{ name, idField string }{
+		{"standings", "teamId"},
+		{"goalscorers", "playerId"},
+		{"assists", "playerId"},
+		{"cleansheets", "playerId"},
+	} {
+		n, err := c.rekey(ctx, coll.name, seasonID, coll.idField)
+		if err != nil {
+			c.fail("Re-key %s: %v", coll.name, err)
+			continue
+		}
+		c.info("%-13s %d re-keyed", coll.name+":", n)
+	}
+
+	// 6. Player statistics so far belong to this season
+	if err := c.copyPlayerStats(ctx, seasonID); err != nil {
+		c.fail("Copy player statistics: %v", err)
+	}
+	return nil
+}
+
+// migrateVerified marks the accounts made before email verification existed as
+// verified, so their owners can keep posting reviews. Accounts made after --before
+// still have to confirm their address. It is safe to run more than once.
+func migrateVerified(c *cli, args []string) error {
+	fs := c.flags("migrate verified")
+	before := fs.String("before", "", "when email verification was deployed, YYYY-MM-DDTHH:MM UTC (required)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	if *before == "" {
+		return fmt.Errorf("%w: --before is required", errUsage)
+	}
+	cutoff, err := time.Parse("2006-01-02T15:04", *before)
+	if err != nil {
+		return fmt.Errorf("%w: invalid --before %q, use YYYY-MM-DDTHH:MM", errUsage, *before)
+	}
+	c.connect()
+
+	c.step("Marking accounts made before %s as verified", cutoff.Format(time.RFC3339))
+	ctx, cancel := c.context()
+	defer cancel()
+	users := database.DB.Collection("users")
+	filter := bson.M{
+		"emailVerifiedAt": nil, // Missing or null
+		"deletedAt":       nil,
+		"$or": bson.A{
+			bson.M{"createdAt": bson.M{"$lt": cutoff}},
+			bson.M{"createdAt": bson.M{"$exists": false}},
+		},
+	}
+	if c.dryRun {
+		n, err := users.CountDocuments(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("count users: %w", err)
+		}
+		c.info("Users:        %d would be marked verified", n)
+		return nil
+	}
+	res, err := users.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"emailVerifiedAt": time.Now()}})
+	if err != nil {
+		return fmt.Errorf("update users: %w", err)
+	}
+	c.info("Users:        %d marked verified", res.ModifiedCount)
+	return nil
+}
+
+// migrateAdmins promotes the ADMINs made before SUPERADMIN existed, who could invite
+// staff and change roles, to SUPERADMIN so they keep users:manage. Each promotion is
+// kept in the role audit trail. It is safe to run more than once.
+func migrateAdmins(c *cli, args []string) error {
+	fs := c.flags("migrate admins")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Promoting %s users to %s", models.RoleAdmin, models.RoleSuperAdmin)
+	ctx, cancel := c.context()
+	defer cancel()
+	cursor, err := database.DB.Collection("users").Find(ctx, bson.M{"role": models.RoleAdmin, "deletedAt": nil})
+	if err != nil {
+		return fmt.Errorf("find admins: %w", err)
+	}
+	var admins []models.User
+	if err := cursor.All(ctx, &admins); err != nil {
+		return fmt.Errorf("read admins: %w", err)
+	}
+	if c.dryRun {
+		c.info("Users:        %d would be promoted", len(admins))
+		return nil
+	}
+
+	repo := repositories.NewUserRepository()
+	promoted := 0
+	for i := range admins {
+		user := &admins[i]
+		if err := repo.UpdateRole(user.ID, models.RoleSuperAdmin); err != nil {
+			c.fail("Promote %s: %v", user.Email, err)
+			continue
+		}
+		c.recordRoleChange(user, models.RoleAdmin, models.RoleSuperAdmin)
+		promoted++
+	}
+	c.info("Users:        %d promoted", promoted)
+	return nil
+}
+
+func (c *cli) upsertSeason(ctx context.Context, seasonID string, matches []models.Match) error {
+	season := models.Season{
+		ID:       seasonID,
+		Name:     "Premier League 2025/26",
+		Year:     "2025/26",
+		IsActive: true,
+	}
+
+	teams := make(map[string]bool)
+	for _, m := range matches {
+		teams[m.HomeTeamID] = true
+		teams[m.AwayTeamID] = true
+		if season.StartDate.IsZero() || m.Date.Before(season.StartDate) {
+			season.StartDate = m.Date
+		}
+		if m.Date.After(season.EndDate) {
+			season.EndDate = m.Date
+		}
+	}
+	for id := range teams {
+		if id != "" {
+			season.TeamIDs = append(season.TeamIDs, id)
+		}
+	}
+	sort.Strings(season.TeamIDs)
+	c.info("Season:       %s active with %d clubs", seasonID, len(season.TeamIDs))
+	if c.dryRun {
+		return nil
+	}
+
+	// Only one season may be active
+	_, err := database.DB.Collection("seasons").UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$ne": seasonID}},
+		bson.M{"$set": bson.M{"isActive": false}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = database.DB.Collection("seasons").UpdateOne(ctx,
+		bson.M{"_id": seasonID},
+		bson.M{"$set": season},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// matchSeasons maps every match ID to its season
+func (c *cli) matchSeasons(ctx context.Context) (map[string]string, error) {
+	cursor, err := database.DB.Collection("matches").Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"seasonId": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	seasons := make(map[string]string, len(matches))
+	for _, m := range matches {
+		seasons[m.ID] = m.SeasonID
+	}
+	return seasons, nil
+}
+
+// stampSeason gives every document of a collection without a seasonId the season of
+// the match in matchField, or seasonID if the match is unknown
+func (c *cli) stampSeason(ctx context.Context, name, label, matchField, seasonID string, matchSeason map[string]string) error {
+	coll := database.DB.Collection(name)
+	cursor, err := coll.Find(ctx, noSeason, options.Find().SetProjection(bson.M{matchField: 1}))
+	if err != nil {
+		return err
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return err
+	}
+	c.info("%-13s %d without a season", label, len(docs))
+	if c.dryRun {
+		return nil
+	}
+
+	// Seeded goal events carry no match ID; they are all from the seeded season
+	for _, doc := range docs {
+		id := seasonID
+		if matchID, _ := doc[matchField].(string); matchSeason[matchID] != "" {
+			id = matchSeason[matchID]
+		}
+		if _, err := coll.UpdateOne(ctx, bson.M{"_id": doc["_id"]}, bson.M{"$set": bson.M{"seasonId": id}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rekey moves every document without a seasonId to "<season>:<old _id>",
+// storing the old _id in idField
+func (c *cli) rekey(ctx context.Context, name, seasonID, idField string) (int, error) {
+	coll := database.DB.Collection(name)
+	cursor, err := coll.Find(ctx, noSeason)
+	if err != nil {
+		return 0, err
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return 0, err
+	}
+	if c.dryRun {
+		return len(docs), nil
+	}
+
+	for _, doc := range docs {
+		rawID := doc["_id"]
+		oldID := fmt.Sprint(rawID)
+		doc["_id"] = models.SeasonScopedID(seasonID, oldID)
+		doc["seasonId"] = seasonID
+		if v, ok := doc[idField]; !ok || v == "" {
+			doc[idField] = oldID
+		}
+
+		_, err := coll.ReplaceOne(ctx, bson.M{"_id": doc["_id"]}, doc, options.Replace().SetUpsert(true))
+		if err != nil {
+			return 0, err
+		}
+		if _, err := coll.DeleteOne(ctx, bson.M{"_id": rawID}); err != nil {
+			return 0, err
+		}
+	}
+	return len(docs), nil
+}
+
+func (c *cli) copyPlayerStats(ctx context.Context, seasonID string) error {
+	var players []models.Player
+	if err := findAll(ctx, "players", &players); err != nil {
+		return err
+	}
+
+	coll := database.DB.Collection("player_season_stats")
+	copied := 0
+	for _, p := range players {
+		if p.Statistics == (models.PlayerStats{}) {
+			continue
+		}
+		if c.dryRun {
+			copied++
+			continue
+		}
+		stats := models.PlayerSeasonStats{
+			ID:          models.SeasonScopedID(seasonID, p.ID),
+			SeasonID:    seasonID,
+			PlayerID:    p.ID,
+			TeamID:      p.TeamID,
+			PlayerStats: p.Statistics,
+		}
+		// Never overwrite numbers the live stats updates have already written
+		_, err := coll.InsertOne(ctx, stats)
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		copied++
+	}
+	if c.dryRun {
+		c.info("Player stats: up to %d to copy to player_season_stats", copied)
+	} else {
+		c.info("Player stats: %d copied to player_season_stats", copied)
+	}
+	return nil
+}
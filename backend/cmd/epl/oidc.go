@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/oidc"
+)
+
+// oidcMock serves a mock OIDC provider until interrupted, to try single sign-on locally.
+// Point OIDC_ISSUER at its --issuer and OIDC_CLIENT_ID at its --client-id.
+func oidcMock(c *cli, args []string) error {
+	fs := c.flags("oidc mock")
+	addr := fs.String("addr", "localhost:9999", "address to listen on")
+	issuer := fs.String("issuer", "", "issuer URL the provider announces (default: http://<addr>)")
+	clientID := fs.String("client-id", "epl", "the only client ID accepted")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	if *issuer == "" {
+		*issuer = "http://" + *addr
+	}
+
+	provider, err := oidc.NewMockProvider(*issuer, *clientID)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", *addr, err)
+	}
+
+	c.step("Mock OIDC provider at %s", provider.Issuer)
+	c.info("OIDC_ISSUER=%s OIDC_CLIENT_ID=%s", provider.Issuer, provider.ClientID)
+	c.info("Any email address signs in, marked verified; Ctrl-C stops")
+	return http.Serve(ln, provider)
+}
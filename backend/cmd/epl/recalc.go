@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// recalcStandings rebuilds a season's table by replaying its finished matches. Scores are
+// taken as stored, since imported results have no goal events; --from-events recounts
+// each score from the goal events first, as the simulation admin endpoint does.
+func recalcStandings(c *cli, args []string) error {
+	fs := c.flags("recalc standings")
+	season := fs.String("season", "", "season to recalculate (default: the active season)")
+	fromEvents := fs.Bool("from-events", false, "recount every score from its goal events first")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	if *fromEvents && c.dryRun {
+		return fmt.Errorf("%w: --from-events has no dry run", errUsage)
+	}
+	c.connect()
+
+	seasonID, err := services.ResolveSeasonID(*season)
+	if err != nil {
+		return err
+	}
+	c.step("Recalculating %s standings", seasonID)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	if *fromEvents {
+		if err := services.RecalculateSeasonStandings(ctx, seasonID); err != nil {
+			return err
+		}
+		return c.printTable(seasonID)
+	}
+
+	var matches []models.Match
+	cursor, err := database.DB.Collection("matches").Find(ctx, bson.M{"status": models.MatchFinished, "seasonId": seasonID})
+	if err != nil {
+		return fmt.Errorf("fetch matches: %w", err)
+	}
+	if err := cursor.All(ctx, &matches); err != nil {
+		return fmt.Errorf("decode matches: %w", err)
+	}
+	c.info("%d finished matches", len(matches))
+
+	if c.dryRun {
+		return c.printStandings(tally(seasonID, matches))
+	}
+
+	if _, err := database.DB.Collection("standings").DeleteMany(ctx, bson.M{"seasonId": seasonID}); err != nil {
+		return fmt.Errorf("clear standings: %w", err)
+	}
+	for i := range matches {
+		if err := services.UpdateStandings(ctx, &matches[i]); err != nil {
+			c.fail("Match %s: %v", matches[i].ID, err)
+		}
+		if (i+1)%50 == 0 || i+1 == len(matches) {
+			c.progress(i+1, len(matches), "matches replayed")
+		}
+	}
+	return c.printTable(seasonID)
+}
+
+// tally computes the table a season's finished matches give, without touching the database
+func tally(seasonID string, matches []models.Match) []models.Standing {
+	rows := map[string]*models.Standing{}
+	add := func(teamID string, score, oppScore int) {
+		st, ok := rows[teamID]
+		if !ok {
+			st = &models.Standing{ID: models.SeasonScopedID(seasonID, teamID), TeamID: teamID, SeasonID: seasonID}
+			rows[teamID] = st
+		}
+		st.Played++
+		st.GoalsFor += score
+		st.GoalsAgainst += oppScore
+		st.GoalDifference = st.GoalsFor - st.GoalsAgainst
+		switch {
+		case score > oppScore:
+			st.Wins++
+			st.Points += 3
+		case score == oppScore:
+			st.Draws++
+			st.Points++
+		default:
+			st.Losses++
+		}
+	}
+	for _, m := range matches {
+		add(m.HomeTeamID, m.HomeScore, m.AwayScore)
+		add(m.AwayTeamID, m.AwayScore, m.HomeScore)
+	}
+
+	standings := make([]models.Standing, 0, len(rows))
+	for _, st := range rows {
+		standings = append(standings, *st)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.GoalDifference != b.GoalDifference {
+			return a.GoalDifference > b.GoalDifference
+		}
+		return a.GoalsFor > b.GoalsFor
+	})
+	return standings
+}
+
+// printTable prints a season's stored table
+func (c *cli) printTable(seasonID string) error {
+	standings, err := repositories.NewMatchRepository().GetStandings(seasonID)
+	if err != nil {
+		return fmt.Errorf("fetch standings: %w", err)
+	}
+	return c.printStandings(standings)
+}
+
+func (c *cli) printStandings(standings []models.Standing) error {
+	names := map[string]string{}
+	if teams, err := repositories.NewTeamRepository().GetAllTeams(); err == nil {
+		for _, t := range teams {
+			names[t.ID] = t.Name
+		}
+	}
+	c.info("%-3s %-25s %3s %3s %3s %3s %4s %4s", "#", "Team", "P", "W", "D", "L", "GD", "Pts")
+	for i, st := range standings {
+		name := names[st.TeamID]
+		if name == "" {
+			name = st.TeamID
+		}
+		c.info("%-3d %-25s %3d %3d %3d %3d %4d %4d", i+1, name, st.Played, st.Wins, st.Draws, st.Losses, st.GoalDifference, st.Points)
+	}
+	return nil
+}
+
+// CoachDoc is an entry of the coaches collection
+type CoachDoc struct {
+	TeamID   string `bson:"team_id" json:"team_id"`
+	TeamName string `bson:"team_name" json:"team_name"`
+	Name     string `bson:"name" json:"name"`
+}
+
+// StatDoc is an entry of the goalscorers, assists and cleansheets leaderboards
+type StatDoc struct {
+	ID        string `bson:"_id" json:"-"` // SeasonScopedID(SeasonID, PlayerID)
+	PlayerID  string `bson:"playerId" json:"playerId"`
+	SeasonID  string `bson:"seasonId" json:"seasonId"`
+	Name      string `bson:"name" json:"name"`
+	TeamName  string `bson:"teamName" json:"teamName"`
+	TeamID    string `bson:"teamId" json:"teamId"`
+	Value     int    `bson:"count" json:"value"`
+	ImagePath string `bson:"imagePath" json:"imagePath"`
+}
+
+// seasonPlayer is a player together with their statistics for the season being populated
+type seasonPlayer struct {
+	models.Player
+	Season models.PlayerStats
+	TeamID string // Team the player played for that season
+}
+
+// recalcStats rebuilds the coaches collection and a season's top 10 leaderboards
+func recalcStats(c *cli, args []string) error {
+	fs := c.flags("recalc stats")
+	season := fs.String("season", "", "season to rebuild the leaderboards of (default: the active season)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	seasonID, err := services.ResolveSeasonID(*season)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+
+	c.step("Rebuilding coaches")
+	var teams []models.Team
+	if err := findAll(ctx, "teams", &teams); err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+	var coaches []interface{}
+	for _, t := range teams {
+		if t.Coach != "" {
+			coaches = append(coaches, CoachDoc{TeamID: t.ID, TeamName: t.Name, Name: t.Coach})
+		}
+	}
+	c.info("%d coaches", len(coaches))
+	if !c.dryRun {
+		coachColl := database.DB.Collection("coaches")
+		if err := coachColl.Drop(ctx); err != nil {
+			return fmt.Errorf("drop coaches: %w", err)
+		}
+		if len(coaches) > 0 {
+			if _, err := coachColl.InsertMany(ctx, coaches); err != nil {
+				c.fail("Insert coaches: %v", err)
+			}
+		}
+	}
+
+	c.step("Rebuilding %s leaderboards", seasonID)
+	var allPlayers []models.Player
+	if err := findAll(ctx, "players", &allPlayers); err != nil {
+		return fmt.Errorf("fetch players: %w", err)
+	}
+	playerByID := make(map[string]models.Player)
+	for _, p := range allPlayers {
+		playerByID[p.ID] = p
+	}
+
+	cursor, err := database.DB.Collection("player_season_stats").Find(ctx, bson.M{"seasonId": seasonID})
+	if err != nil {
+		return fmt.Errorf("fetch season stats: %w", err)
+	}
+	var seasonStats []models.PlayerSeasonStats
+	if err := cursor.All(ctx, &seasonStats); err != nil {
+		return fmt.Errorf("decode season stats: %w", err)
+	}
+
+	var players []seasonPlayer
+	for _, st := range seasonStats {
+		p, ok := playerByID[st.PlayerID]
+		if !ok {
+			continue
+		}
+		teamID := st.TeamID
+		if teamID == "" {
+			teamID = p.TeamID
+		}
+		players = append(players, seasonPlayer{Player: p, Season: st.PlayerStats, TeamID: teamID})
+	}
+
+	// Map team names for display
+	teamMap := make(map[string]string)
+	for _, t := range teams {
+		teamMap[t.ID] = t.Name
+	}
+
+	for _, board := range []struct {
+		collection string
+		value      func(seasonPlayer) int
+	}{
+		{"goalscorers", func(p seasonPlayer) int { return p.Season.Goals }},
+		{"assists", func(p seasonPlayer) int { return p.Season.Assists }},
+		{"cleansheets", func(p seasonPlayer) int { return p.Season.CleanSheets }},
+	} {
+		sort.SliceStable(players, func(i, j int) bool {
+			return board.value(players[i]) > board.value(players[j])
+		})
+
+		var docs []interface{}
+		for _, p := range players {
+			if len(docs) >= 10 || board.value(p) == 0 {
+				break
+			}
+			docs = append(docs, StatDoc{
+				ID:        models.SeasonScopedID(seasonID, p.ID),
+				PlayerID:  p.ID,
+				SeasonID:  seasonID,
+				Name:      p.DisplayName,
+				TeamName:  teamMap[p.TeamID],
+				TeamID:    p.TeamID,
+				Value:     board.value(p),
+				ImagePath: p.ImagePath,
+			})
+		}
+		if len(docs) > 0 {
+			top := docs[0].(StatDoc)
+			c.info("%s: %d entries, top %s (%s) with %d", board.collection, len(docs), top.Name, top.TeamName, top.Value)
+		} else {
+			c.info("%s: no entries", board.collection)
+		}
+		if c.dryRun {
+			continue
+		}
+
+		coll := database.DB.Collection(board.collection)
+		if _, err := coll.DeleteMany(ctx, bson.M{"seasonId": seasonID}); err != nil {
+			c.fail("Clear %s: %v", board.collection, err)
+			continue
+		}
+		if len(docs) > 0 {
+			if _, err := coll.InsertMany(ctx, docs); err != nil {
+				c.fail("Insert %s: %v", board.collection, err)
+			}
+		}
+	}
+	return nil
+}
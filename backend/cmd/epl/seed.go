@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/sportmonks"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// seedAll builds a database from the repository's data files, in the order each step needs
+func seedAll(c *cli, args []string) error {
+	fs := c.flags("seed all")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	for _, step := range []func(*cli, []string) error{seedTeams, seedAliases, seedSquad, seedCoaches, seedMatches, seedStats, recalcStats} {
+		if err := step(c, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedTeams loads the SportMonks league table saved in ptext.txt
+func seedTeams(c *cli, args []string) error {
+	fs := c.flags("seed teams")
+	file := fs.String("file", "ptext.txt", "SportMonks standings JSON")
+	season := fs.String("season", services.DefaultSeasonID, "season the table belongs to")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Seeding teams and standings")
+	var standings []sportmonks.Standing
+	if err := c.readJSON(*file, &standings); err != nil {
+		return err
+	}
+	c.info("Found %d standings entries", len(standings))
+
+	ctx, cancel := c.context()
+	defer cancel()
+	teams, err := repositories.NewTeamRepository().GetAllTeams()
+	if err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+	importer := services.NewSportMonksImporter(nil, teams)
+	importer.DryRun = c.dryRun
+	res, err := importer.SaveStandings(ctx, *season, standings)
+	if err != nil {
+		return err
+	}
+	c.info("Standings: %s", res)
+	return nil
+}
+
+// Common variants used by results files, openfootball and the press.
+// Official names with "FC"/"AFC" don't need listing: the resolver ignores those words.
+var defaultAliases = map[string][]string{
+	"Arsenal":                 {"The Gunners"},
+	"Aston Villa":             {"Villa"},
+	"AFC Bournemouth":         {"Bournemouth"},
+	"Brentford":               {},
+	"Brighton & Hove Albion":  {"Brighton", "Brighton and Hove Albion", "Brighton & Hove"},
+	"Burnley":                 {},
+	"Chelsea":                 {},
+	"Crystal Palace":          {"Palace"},
+	"Everton":                 {},
+	"Fulham":                  {},
+	"Leeds United":            {"Leeds", "Leeds Utd"},
+	"Liverpool":               {},
+	"Manchester City":         {"Man City", "Man. City", "Manchester C"},
+	"Manchester United":       {"Man United", "Man Utd", "Man. United", "Manchester Utd"},
+	"Newcastle United":        {"Newcastle", "Newcastle Utd"},
+	"Nottingham Forest":       {"Nott'm Forest", "Nottm Forest", "Forest", "Notts Forest"},
+	"Sunderland":              {},
+	"Tottenham Hotspur":       {"Tottenham", "Spurs"},
+	"West Ham United":         {"West Ham", "West Ham Utd"},
+	"Wolverhampton Wanderers": {"Wolves", "Wolverhampton"},
+}
+
+// seedAliases seeds the team alias registry. Existing aliases are kept; team IDs come
+// from SportMonks, so each team without one records its own ID as its "sportmonks" ID.
+func seedAliases(c *cli, args []string) error {
+	fs := c.flags("seed aliases")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Seeding team aliases")
+	ctx, cancel := c.context()
+	defer cancel()
+	teams, err := repositories.NewTeamRepository().GetAllTeams()
+	if err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+
+	col := database.DB.Collection("teams")
+	for i, team := range teams {
+		aliases, ok := defaultAliases[team.Name]
+		if !ok {
+			c.warn("No default aliases for %s", team.Name)
+		}
+		c.progress(i+1, len(teams), "%s: %d aliases", team.Name, len(aliases))
+		if c.dryRun {
+			continue
+		}
+
+		set := bson.M{}
+		if _, ok := team.ExternalIDs["sportmonks"]; !ok {
+			set["externalIds.sportmonks"] = team.ID
+		}
+		update := bson.M{}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+		if len(aliases) > 0 {
+			update["$addToSet"] = bson.M{"aliases": bson.M{"$each": aliases}}
+		}
+		if len(update) == 0 {
+			continue
+		}
+		if _, err := col.UpdateOne(ctx, bson.M{"_id": team.ID}, update); err != nil {
+			c.fail("Update aliases of %s: %v", team.Name, err)
+		}
+	}
+	return nil
+}
+
+// seedSquad loads one team's players from a saved SportMonks squad response
+func seedSquad(c *cli, args []string) error {
+	fs := c.flags("seed squad")
+	file := fs.String("file", "mancitysquad.json", "SportMonks squad JSON")
+	teamID := fs.String("team", "9", "team the squad belongs to (default: Manchester City)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Seeding the squad of team %s", *teamID)
+	var squad []sportmonks.SquadEntry
+	if err := c.readJSON(*file, &squad); err != nil {
+		return err
+	}
+	c.info("Found %d players", len(squad))
+
+	ctx, cancel := c.context()
+	defer cancel()
+	importer := services.NewSportMonksImporter(nil, nil)
+	importer.DryRun = c.dryRun
+	res, err := importer.SaveSquad(ctx, *teamID, squad)
+	if err != nil {
+		return err
+	}
+	c.info("Players: %s", res)
+	return nil
+}
+
+// Head coaches set when a database is first seeded
+var defaultCoaches = map[string]string{
+	"Arsenal":         "Mikel Arteta",
+	"Manchester City": "Pep Guardiola",
+	"Chelsea":         "Casey Stoney",
+}
+
+func seedCoaches(c *cli, args []string) error {
+	fs := c.flags("seed coaches")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+
+	c.step("Assigning coaches")
+	ctx, cancel := c.context()
+	defer cancel()
+	col := database.DB.Collection("teams")
+	for teamName, coach := range defaultCoaches {
+		var team models.Team
+		if err := col.FindOne(ctx, bson.M{"name": teamName}).Decode(&team); err != nil {
+			c.fail("Team %s not found: %v", teamName, err)
+			continue
+		}
+		c.info("%s: %s", teamName, coach)
+		if c.dryRun {
+			continue
+		}
+		if _, err := col.UpdateOne(ctx, bson.M{"_id": team.ID}, bson.M{"$set": bson.M{"coach": coach}}); err != nil {
+			c.fail("Update coach of %s: %v", teamName, err)
+		}
+	}
+	return nil
+}
+
+// seedFixtures generates a season's fixtures as a 38-matchday double round-robin
+func seedFixtures(c *cli, args []string) error {
+	fs := c.flags("seed fixtures")
+	season := fs.String("season", "", "season to generate fixtures for (default: the active season)")
+	start := fs.String("start", "", "kick-off of matchday 1, e.g. 2026-08-15T15:00 (required)")
+	days := fs.Int("days", 7, "days between matchdays")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "random seed; the same seed gives the same fixtures")
+	teams := fs.String("teams", "", "comma-separated team IDs (default: the season's clubs)")
+	pairs := fs.String("pairs", "", "clubs never at home on the same day, e.g. 19:6,14:2")
+	replace := fs.Bool("replace", false, "replace the season's existing fixtures")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+
+	startDate, err := time.Parse("2006-01-02T15:04", *start)
+	if err != nil {
+		return fmt.Errorf("%w: invalid --start %q, use YYYY-MM-DDTHH:MM", errUsage, *start)
+	}
+	req := services.GenerateFixturesRequest{
+		StartDate:   startDate,
+		DaysBetween: *days,
+		Seed:        *seed,
+		DryRun:      c.dryRun,
+		Replace:     *replace,
+	}
+	if *teams != "" {
+		req.TeamIDs = strings.Split(*teams, ",")
+	}
+	for _, p := range strings.Split(*pairs, ",") {
+		if p == "" {
+			continue
+		}
+		ids := strings.Split(p, ":")
+		if len(ids) != 2 {
+			return fmt.Errorf("%w: invalid pair %q, use teamId:teamId", errUsage, p)
+		}
+		req.Pairs = append(req.Pairs, [2]string{ids[0], ids[1]})
+	}
+	c.connect()
+
+	c.step("Generating fixtures")
+	result, err := services.NewSeasonService().GenerateFixtures(*season, req)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string)
+	if teams, err := repositories.NewTeamRepository().GetAllTeams(); err == nil {
+		for _, t := range teams {
+			names[t.ID] = t.Name
+		}
+	}
+	matchday := 0
+	for _, f := range result.Fixtures {
+		if f.Matchday != matchday {
+			matchday = f.Matchday
+			c.info("Matchday %d, %s", f.Matchday, f.Date.Format("Mon 02 Jan 2006 15:04"))
+		}
+		c.info("  %-25s v %s", names[f.HomeTeamID], names[f.AwayTeamID])
+	}
+	if result.Saved {
+		c.info("Saved %d fixtures for %s (seed %d)", len(result.Fixtures), result.SeasonID, *seed)
+	} else {
+		c.info("%d fixtures for %s (seed %d), not saved", len(result.Fixtures), result.SeasonID, *seed)
+	}
+	return nil
+}
+
+// readJSON decodes one of the repository's data files
+func (c *cli) readJSON(name string, v interface{}) error {
+	path, err := c.dataFile(name)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	c.info("Read %s", path)
+	return nil
+}
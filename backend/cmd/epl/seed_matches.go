@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/openfootball"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RawMatch is an entry of results.json or next_matches.json
+type RawMatch struct {
+	Matchday      int     `json:"matchday"`
+	Date          string  `json:"date"` // e.g. "Fri Aug/15", the year is implied by the season
+	Time          string  `json:"time"` // e.g. "20.00"
+	HomeTeam      string  `json:"homeTeam"`
+	AwayTeam      string  `json:"awayTeam"`
+	HomeScore     *int    `json:"homeScore"`
+	AwayScore     *int    `json:"awayScore"`
+	HalfTimeScore *string `json:"halfTimeScore"`
+}
+
+// seedMatches imports fixtures and results into the matches collection
+func seedMatches(c *cli, args []string) error {
+	fs := c.flags("seed matches")
+	from := fs.String("from", "openfootball", "source to import: openfootball or json (results.json + next_matches.json)")
+	file := fs.String("file", "england-master/2025-26/1-premierleague.txt", "openfootball .txt file (with --from openfootball)")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	if *from != "json" && *from != "openfootball" {
+		return fmt.Errorf("%w: unknown source %q, use openfootball or json", errUsage, *from)
+	}
+	c.connect()
+
+	c.step("Seeding matches from %s", *from)
+	teams, err := repositories.NewTeamRepository().GetAllTeams()
+	if err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+	// Resolve source team names through the team alias registry
+	resolver := services.NewTeamResolver(teams)
+	defer c.reportUnmatched(resolver)
+
+	ctx, cancel := c.context()
+	defer cancel()
+	if *from == "openfootball" {
+		return c.importOpenfootball(ctx, *file, resolver)
+	}
+	return c.importJSONMatches(ctx, resolver)
+}
+
+// importJSONMatches replaces every match with the contents of results.json and next_matches.json
+func (c *cli) importJSONMatches(ctx context.Context, resolver *services.TeamResolver) error {
+	var results, fixtures []RawMatch
+	if err := c.readJSON("results.json", &results); err != nil {
+		return err
+	}
+	if err := c.readJSON("next_matches.json", &fixtures); err != nil {
+		return err
+	}
+
+	var matches []interface{}
+	for _, file := range []struct {
+		entries []RawMatch
+		status  models.MatchStatus
+	}{{results, models.MatchFinished}, {fixtures, models.MatchScheduled}} {
+		for _, m := range file.entries {
+			homeID, ok1 := resolver.ResolveID(m.HomeTeam)
+			awayID, ok2 := resolver.ResolveID(m.AwayTeam)
+			if !ok1 || !ok2 {
+				c.warn("Skipping %s v %s: team not found", m.HomeTeam, m.AwayTeam)
+				continue
+			}
+			date, err := parseRawDate(m.Date, m.Time)
+			if err != nil {
+				c.fail("Matchday %d, %s v %s: %v", m.Matchday, m.HomeTeam, m.AwayTeam, err)
+				continue
+			}
+
+			match := models.Match{
+				ID:         fmt.Sprintf("M%d_%s_%s", m.Matchday, homeID, awayID),
+				HomeTeamID: homeID,
+				AwayTeamID: awayID,
+				Matchday:   m.Matchday,
+				Date:       date,
+				Status:     file.status,
+				SeasonID:   services.DefaultSeasonID,
+			}
+			if file.status == models.MatchFinished && m.HomeScore != nil && m.AwayScore != nil {
+				match.HomeScore = *m.HomeScore
+				match.AwayScore = *m.AwayScore
+			}
+			matches = append(matches, match)
+		}
+	}
+	c.info("%d results and %d fixtures, %d matches resolved", len(results), len(fixtures), len(matches))
+	if c.dryRun {
+		return nil
+	}
+
+	coll := database.DB.Collection("matches")
+	if err := coll.Drop(ctx); err != nil {
+		return fmt.Errorf("drop matches: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	res, err := coll.InsertMany(ctx, matches, options.InsertMany().SetOrdered(false))
+	if res != nil {
+		c.info("Inserted %d matches", len(res.InsertedIDs))
+	}
+	if err != nil {
+		c.fail("Insert matches: %v", err)
+	}
+	return nil
+}
+
+// parseRawDate reads the "Fri Aug/15" + "20.00" dates of the JSON files. They carry no
+// year: August to December are 2025, the rest 2026.
+func parseRawDate(date, clock string) (time.Time, error) {
+	if !strings.Contains(date, "202") {
+		year := " 2026"
+		for _, month := range []string{"Aug", "Sep", "Oct", "Nov", "Dec"} {
+			if strings.Contains(date, month) {
+				year = " 2025"
+			}
+		}
+		date += year
+	}
+	clock = strings.Replace(clock, ".", ":", 1)
+	if clock == "" {
+		clock = "15:00" // Default time
+	}
+
+	full := date + " " + clock
+	for _, layout := range []string{"Mon Jan/02 2006 15:04", "Mon Jan/2 2006 15:04"} {
+		if t, err := time.Parse(layout, full); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", full)
+}
+
+// importOpenfootball upserts every match of an openfootball fixture file. Fixture details
+// are always refreshed; results only overwrite a match once the file has a score for it,
+// so matches that are live or simulated locally keep their state.
+func (c *cli) importOpenfootball(ctx context.Context, file string, resolver *services.TeamResolver) error {
+	path, err := c.dataFile(file)
+	if err != nil {
+		return err
+	}
+	season, err := openfootball.ParseFile(path)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	c.info("Read %s: %d matches", path, len(season.Matches))
+
+	seasonID := models.NormalizeSeasonID(season.Season)
+	if seasonID == "" {
+		seasonID = services.DefaultSeasonID
+	}
+
+	coll := database.DB.Collection("matches")
+	inserted, updated, skipped := 0, 0, 0
+	for _, m := range season.Matches {
+		homeID, ok1 := resolver.ResolveID(m.HomeTeam)
+		awayID, ok2 := resolver.ResolveID(m.AwayTeam)
+		if !ok1 || !ok2 {
+			c.warn("Line %d: skipping %s v %s: team not found", m.Line, m.HomeTeam, m.AwayTeam)
+			skipped++
+			continue
+		}
+		if c.dryRun {
+			continue
+		}
+
+		id := fmt.Sprintf("M%d_%s_%s", m.Matchday, homeID, awayID)
+		ins, upd, err := upsertOpenfootballMatch(ctx, coll, id, seasonID, homeID, awayID, m)
+		if err != nil {
+			c.fail("Line %d: %s: %v", m.Line, id, err)
+			continue
+		}
+		inserted += ins
+		updated += upd
+	}
+
+	if c.dryRun {
+		c.info("%s: %d matches would be imported, %d skipped", seasonID, len(season.Matches)-skipped, skipped)
+	} else {
+		c.info("%s: %d inserted, %d updated, %d skipped", seasonID, inserted, updated, skipped)
+	}
+	return nil
+}
+
+func upsertOpenfootballMatch(ctx context.Context, coll *mongo.Collection, id, seasonID, homeID, awayID string, m openfootball.Match) (inserted, updated int, err error) {
+	date := m.Date
+	if !m.HasTime {
+		y, mo, d := date.In(openfootball.Location).Date()
+		date = time.Date(y, mo, d, 15, 0, 0, 0, openfootball.Location).UTC() // Default time
+	}
+
+	set := bson.M{
+		"homeTeamId": homeID,
+		"awayTeamId": awayID,
+		"matchday":   m.Matchday,
+		"date":       date,
+		"seasonId":   seasonID,
+	}
+	setOnInsert := bson.M{}
+
+	switch m.Status {
+	case openfootball.StatusPlayed:
+		set["status"] = models.MatchFinished
+		set["homeScore"] = m.Score.Home
+		set["awayScore"] = m.Score.Away
+		if m.HalfTime != nil {
+			set["halfTimeScore"] = m.HalfTime.String()
+		}
+	case openfootball.StatusScheduled:
+		setOnInsert["status"] = models.MatchScheduled
+		setOnInsert["homeScore"] = 0
+		setOnInsert["awayScore"] = 0
+	default:
+		// The file keeps a postponed match on its original date; don't move a
+		// match that has already been rescheduled here
+		delete(set, "date")
+		setOnInsert["date"] = date
+		setOnInsert["status"] = models.MatchStatus(m.Status)
+		setOnInsert["homeScore"] = 0
+		setOnInsert["awayScore"] = 0
+	}
+
+	update := bson.M{"$set": set}
+	if len(setOnInsert) > 0 {
+		update["$setOnInsert"] = setOnInsert
+	}
+	res, err := coll.UpdateOne(ctx, bson.M{"_id": id}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return 0, 0, err
+	}
+	if res.UpsertedCount > 0 {
+		inserted = 1
+	} else if res.ModifiedCount > 0 {
+		updated = 1
+	}
+
+	// A match already in the database only picks up the new status while it is still scheduled
+	if m.Status != openfootball.StatusPlayed && m.Status != openfootball.StatusScheduled && res.UpsertedCount == 0 {
+		res, err := coll.UpdateOne(ctx,
+			bson.M{"_id": id, "status": models.MatchScheduled, "originalDate": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"status": models.MatchStatus(m.Status)}},
+		)
+		if err != nil {
+			return inserted, updated, fmt.Errorf("update status: %w", err)
+		}
+		if res.ModifiedCount > 0 {
+			updated = 1
+		}
+	}
+	return inserted, updated, nil
+}
+
+// reportUnmatched lists the team names no team or alias matched, so they can be added as aliases
+func (c *cli) reportUnmatched(resolver *services.TeamResolver) {
+	for _, u := range resolver.Unmatched() {
+		c.warn("Team not found: %q (%d times), add it as an alias", u.Name, u.Count)
+	}
+}
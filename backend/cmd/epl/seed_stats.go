@@ -0,0 +1,399 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// playerTotals is one player's generated goals, assists and clean sheets
+type playerTotals struct {
+	Goals       int
+	Assists     int
+	CleanSheets int
+}
+
+// seedStats copies results.json and next_matches.json into the database, generates goal
+// events for every result and derives the players' statistics from them
+func seedStats(c *cli, args []string) error {
+	fs := c.flags("seed stats")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "random seed; the same seed gives the same scorers")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	c.connect()
+	rng := rand.New(rand.NewSource(*seed))
+
+	c.step("Seeding goal events and player statistics")
+	ctx, cancel := c.context()
+	defer cancel()
+
+	var teams []models.Team
+	if err := findAll(ctx, "teams", &teams); err != nil {
+		return fmt.Errorf("fetch teams: %w", err)
+	}
+	var players []models.Player
+	if err := findAll(ctx, "players", &players); err != nil {
+		return fmt.Errorf("fetch players: %w", err)
+	}
+	c.info("Loaded %d teams, %d players", len(teams), len(players))
+
+	// Resolve source team names through the team alias registry
+	resolver := services.NewTeamResolver(teams)
+	defer c.reportUnmatched(resolver)
+
+	playersByTeam := map[string][]models.Player{}
+	playerTeams := map[string]string{}
+	for _, p := range players {
+		playersByTeam[p.TeamID] = append(playersByTeam[p.TeamID], p)
+		playerTeams[p.ID] = p.TeamID
+	}
+
+	var results, nextMatches []RawMatch
+	if err := c.readJSON("results.json", &results); err != nil {
+		return err
+	}
+	if err := c.readJSON("next_matches.json", &nextMatches); err != nil {
+		return err
+	}
+
+	// The fixture files are the current season
+	seasonID := services.DefaultSeasonID
+	events, matchesWithGoals := generateGoalEvents(rng, results, resolver, playersByTeam, seasonID)
+	stats := playerStatistics(events, results, resolver, playersByTeam)
+	c.info("%d results, %d next matches", len(results), len(nextMatches))
+	c.info("%d goal events from %d matches, statistics for %d players", len(events), matchesWithGoals, len(stats))
+	if c.dryRun {
+		return nil
+	}
+
+	if err := c.replaceRawMatches(ctx, results, nextMatches); err != nil {
+		return err
+	}
+
+	goals := database.DB.Collection("goal_events")
+	if err := goals.Drop(ctx); err != nil {
+		return fmt.Errorf("drop goal_events: %w", err)
+	}
+	// Insert in batches of 500
+	const batchSize = 500
+	for i := 0; i < len(events); i += batchSize {
+		end := i + batchSize
+		if end > len(events) {
+			end = len(events)
+		}
+		batch := make([]interface{}, 0, end-i)
+		for _, e := range events[i:end] {
+			batch = append(batch, e)
+		}
+		if _, err := goals.InsertMany(ctx, batch); err != nil {
+			c.fail("Insert goal events %d-%d: %v", i+1, end, err)
+		}
+		c.progress(end, len(events), "goal events inserted")
+	}
+
+	playerColl := database.DB.Collection("players")
+	seasonStatsColl := database.DB.Collection("player_season_stats")
+	if _, err := seasonStatsColl.DeleteMany(ctx, bson.M{"seasonId": seasonID}); err != nil {
+		return fmt.Errorf("clear %s player stats: %w", seasonID, err)
+	}
+	for pID, s := range stats {
+		_, err := playerColl.UpdateOne(ctx,
+			bson.M{"_id": pID},
+			bson.M{"$set": bson.M{
+				"statistics.goals":       s.Goals,
+				"statistics.assists":     s.Assists,
+				"statistics.cleanSheets": s.CleanSheets,
+			}},
+		)
+		if err != nil {
+			c.fail("Update stats of player %s: %v", pID, err)
+			continue
+		}
+
+		_, err = seasonStatsColl.UpdateOne(ctx,
+			bson.M{"_id": models.SeasonScopedID(seasonID, pID)},
+			bson.M{"$set": bson.M{
+				"seasonId":    seasonID,
+				"playerId":    pID,
+				"teamId":      playerTeams[pID],
+				"goals":       s.Goals,
+				"assists":     s.Assists,
+				"cleanSheets": s.CleanSheets,
+			}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			c.fail("Update %s stats of player %s: %v", seasonID, pID, err)
+		}
+	}
+	c.info("Updated statistics of %d players", len(stats))
+	return nil
+}
+
+// replaceRawMatches stores the JSON files as they are in the results and next_matches collections
+func (c *cli) replaceRawMatches(ctx context.Context, results, nextMatches []RawMatch) error {
+	for _, coll := range []struct {
+		name    string
+		prefix  string
+		entries []RawMatch
+	}{{"results", "result", results}, {"next_matches", "next", nextMatches}} {
+		col := database.DB.Collection(coll.name)
+		if err := col.Drop(ctx); err != nil {
+			return fmt.Errorf("drop %s: %w", coll.name, err)
+		}
+		var docs []interface{}
+		for i, r := range coll.entries {
+			doc := bson.M{
+				"_id":      fmt.Sprintf("%s-%d", coll.prefix, i),
+				"matchday": r.Matchday,
+				"date":     r.Date,
+				"time":     r.Time,
+				"homeTeam": r.HomeTeam,
+				"awayTeam": r.AwayTeam,
+			}
+			if coll.name == "results" {
+				doc["homeScore"] = r.HomeScore
+				doc["awayScore"] = r.AwayScore
+				doc["halfTimeScore"] = r.HalfTimeScore
+			}
+			docs = append(docs, doc)
+		}
+		if len(docs) == 0 {
+			continue
+		}
+		if _, err := col.InsertMany(ctx, docs); err != nil {
+			c.fail("Insert %s: %v", coll.name, err)
+			continue
+		}
+		c.info("Stored %d %s", len(docs), coll.name)
+	}
+	return nil
+}
+
+// generateGoalEvents invents a scorer, an assist (70% of goals) and a minute for every goal of every result
+func generateGoalEvents(rng *rand.Rand, results []RawMatch, resolver *services.TeamResolver, playersByTeam map[string][]models.Player, seasonID string) ([]models.GoalEvent, int) {
+	var events []models.GoalEvent
+	matchesWithGoals := 0
+
+	for matchIdx, match := range results {
+		homeScore, awayScore := score(match.HomeScore), score(match.AwayScore)
+		if homeScore == 0 && awayScore == 0 {
+			continue
+		}
+
+		homeTeam, okHome := resolver.Resolve(match.HomeTeam)
+		awayTeam, okAway := resolver.Resolve(match.AwayTeam)
+		if !okHome || !okAway {
+			continue
+		}
+		homePlayers := playersByTeam[homeTeam.ID]
+		awayPlayers := playersByTeam[awayTeam.ID]
+		if len(homePlayers) == 0 || len(awayPlayers) == 0 {
+			continue
+		}
+
+		matchesWithGoals++
+		usedMinutes := map[int]bool{}
+		for _, side := range []struct {
+			goals   int
+			team    *models.Team
+			players []models.Player
+			home    bool
+		}{{homeScore, homeTeam, homePlayers, true}, {awayScore, awayTeam, awayPlayers, false}} {
+			for g := 0; g < side.goals; g++ {
+				scorer := pickScorer(rng, side.players)
+				if scorer == nil {
+					continue
+				}
+
+				event := models.GoalEvent{
+					ID:         fmt.Sprintf("goal-%d-%d", matchIdx, len(events)),
+					MatchIndex: matchIdx,
+					Matchday:   match.Matchday,
+					HomeTeam:   match.HomeTeam,
+					AwayTeam:   match.AwayTeam,
+					ScorerID:   scorer.ID,
+					ScorerName: scorer.DisplayName,
+					TeamName:   side.team.Name,
+					TeamID:     side.team.ID,
+					Minute:     randomMinute(rng, usedMinutes),
+					IsHomeGoal: side.home,
+					SeasonID:   seasonID,
+				}
+				// 70% chance of assist
+				if rng.Float64() < 0.7 {
+					if assister := pickAssister(rng, side.players, scorer.ID); assister != nil {
+						event.AssistID = assister.ID
+						event.AssistName = assister.DisplayName
+					}
+				}
+				events = append(events, event)
+			}
+		}
+	}
+
+	// Sort goal events by minute within each match
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].MatchIndex != events[j].MatchIndex {
+			return events[i].MatchIndex < events[j].MatchIndex
+		}
+		return events[i].Minute < events[j].Minute
+	})
+	return events, matchesWithGoals
+}
+
+// playerStatistics totals the goals and assists of the events, and gives each team's
+// goalkeeper a clean sheet for every result the team didn't concede in
+func playerStatistics(events []models.GoalEvent, results []RawMatch, resolver *services.TeamResolver, playersByTeam map[string][]models.Player) map[string]playerTotals {
+	stats := map[string]playerTotals{}
+	for _, event := range events {
+		s := stats[event.ScorerID]
+		s.Goals++
+		stats[event.ScorerID] = s
+
+		if event.AssistID != "" {
+			a := stats[event.AssistID]
+			a.Assists++
+			stats[event.AssistID] = a
+		}
+	}
+
+	cleanSheet := func(teamName string) {
+		team, ok := resolver.Resolve(teamName)
+		if !ok {
+			return
+		}
+		if gk := goalkeeper(playersByTeam[team.ID]); gk != nil {
+			s := stats[gk.ID]
+			s.CleanSheets++
+			stats[gk.ID] = s
+		}
+	}
+	for _, match := range results {
+		if score(match.AwayScore) == 0 {
+			cleanSheet(match.HomeTeam)
+		}
+		if score(match.HomeScore) == 0 {
+			cleanSheet(match.AwayTeam)
+		}
+	}
+	return stats
+}
+
+func score(s *int) int {
+	if s == nil {
+		return 0
+	}
+	return *s
+}
+
+// goalkeeper finds a team's keeper: the first player listed as one, or wearing number 1
+func goalkeeper(players []models.Player) *models.Player {
+	for i := range players {
+		if strings.Contains(strings.ToLower(players[i].Position), "goalkeeper") || players[i].Number == 1 {
+			return &players[i]
+		}
+	}
+	return nil
+}
+
+// pickScorer selects a random player weighted by position
+func pickScorer(rng *rand.Rand, players []models.Player) *models.Player {
+	return pickWeighted(rng, players, func(p *models.Player) int {
+		pos := strings.ToLower(p.Position)
+		switch {
+		case strings.Contains(pos, "attack") || strings.Contains(pos, "forward") || strings.Contains(pos, "striker"):
+			return 50
+		case strings.Contains(pos, "midfield"):
+			return 35
+		case strings.Contains(pos, "defend"):
+			return 15
+		case strings.Contains(pos, "goal"):
+			return 0
+		default:
+			return 20
+		}
+	})
+}
+
+// pickAssister picks a player different from the scorer
+func pickAssister(rng *rand.Rand, players []models.Player, scorerID string) *models.Player {
+	return pickWeighted(rng, players, func(p *models.Player) int {
+		if p.ID == scorerID {
+			return 0
+		}
+		pos := strings.ToLower(p.Position)
+		switch {
+		case strings.Contains(pos, "midfield"):
+			return 40
+		case strings.Contains(pos, "attack") || strings.Contains(pos, "forward") || strings.Contains(pos, "striker"):
+			return 35
+		case strings.Contains(pos, "defend"):
+			return 20
+		case strings.Contains(pos, "goal"):
+			return 5
+		default:
+			return 20
+		}
+	})
+}
+
+// pickWeighted picks a random player with probability proportional to weight; players
+// weighing 0 are never picked
+func pickWeighted(rng *rand.Rand, players []models.Player, weight func(*models.Player) int) *models.Player {
+	total := 0
+	for i := range players {
+		total += weight(&players[i])
+	}
+	if total == 0 {
+		return nil
+	}
+
+	r := rng.Intn(total)
+	for i := range players {
+		w := weight(&players[i])
+		if r < w {
+			return &players[i]
+		}
+		r -= w
+	}
+	return nil
+}
+
+// randomMinute generates a unique minute 1-90
+func randomMinute(rng *rand.Rand, used map[int]bool) int {
+	for i := 0; i < 100; i++ {
+		m := rng.Intn(90) + 1
+		if !used[m] {
+			used[m] = true
+			return m
+		}
+	}
+	// Fallback: find any unused minute
+	for m := 1; m <= 90; m++ {
+		if !used[m] {
+			used[m] = true
+			return m
+		}
+	}
+	return 45
+}
+
+// findAll decodes every document of a collection
+func findAll(ctx context.Context, collection string, out interface{}) error {
+	cursor, err := database.DB.Collection(collection).Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	return cursor.All(ctx, out)
+}
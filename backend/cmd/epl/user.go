@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// createAdmin creates a staff user, or gives an existing user a staff role (SUPERADMIN by
+// default). Without --password a random one is generated and printed once.
+func createAdmin(c *cli, args []string) error {
+	fs := c.flags("user create-admin")
+	email := fs.String("email", "", "email of the admin (required)")
+	password := fs.String("password", "", "password for a new admin (default: a random one, printed once)")
+	name := fs.String("name", "Administrator", "full name for a new admin")
+	role := fs.String("role", models.RoleSuperAdmin, "role to give the user")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+	*email = strings.TrimSpace(*email)
+	if *email == "" {
+		return fmt.Errorf("%w: --email is required", errUsage)
+	}
+	if !models.ValidRole(*role) || *role == models.RoleUser {
+		return fmt.Errorf("%w: --role %q is not a staff role", errUsage, *role)
+	}
+	c.connect()
+
+	repo := repositories.NewUserRepository()
+	existing, err := repo.GetUserByEmail(*email)
+	switch {
+	case err == nil:
+		c.step("Promoting %s", *email)
+		if existing.Role == *role {
+			c.info("%s is already %s", *email, *role)
+			return nil
+		}
+		if *password != "" {
+			c.warn("%s already exists, --password ignored", *email)
+		}
+		c.info("Role %s -> %s", existing.Role, *role)
+		if c.dryRun {
+			return nil
+		}
+		if err := repo.UpdateRole(existing.ID, *role); err != nil {
+			return err
+		}
+		c.recordRoleChange(existing, existing.Role, *role)
+		return nil
+	case !errors.Is(err, mongo.ErrNoDocuments):
+		return fmt.Errorf("look up %s: %w", *email, err)
+	}
+
+	c.step("Creating %s %s", *role, *email)
+	generated := *password == ""
+	if generated {
+		if *password, err = randomPassword(); err != nil {
+			return err
+		}
+	}
+	if c.dryRun {
+		return nil
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	verified := time.Now() // Made by an operator, who knows the address
+	user := &models.User{
+		Email:           *email,
+		EmailVerifiedAt: &verified,
+		Password:        string(hashed),
+		FullName:        *name,
+		Role:            *role,
+	}
+	if err := repo.CreateUser(user); err != nil {
+		return fmt.Errorf("create %s: %w", *email, err)
+	}
+	c.recordRoleChange(user, "", *role)
+	if generated {
+		c.info("Password: %s (shown once, change it after logging in)", *password)
+	}
+	return nil
+}
+
+// recordRoleChange adds the change to the role audit trail the admin API keeps
+func (c *cli) recordRoleChange(user *models.User, from, to string) {
+	err := repositories.NewRoleChangeRepository().CreateRoleChange(&models.RoleChange{
+		UserID: user.ID.Hex(),
+		Email:  user.Email,
+		From:   from,
+		To:     to,
+		Via:    models.RoleChangeCLI,
+	})
+	if err != nil {
+		c.fail("Record role change of %s: %v", user.Email, err)
+	}
+}
+
+func randomPassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
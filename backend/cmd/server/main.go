@@ -6,6 +6,7 @@ import (
 	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/routes"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,6 +17,16 @@ func main() {
 	// Connect to Database
 	database.ConnectDB(cfg)
 
+	// Start matches at kickoff
+	if cfg.SchedulerEnabled {
+		scheduler, err := services.NewKickoffScheduler(cfg)
+		if err != nil {
+			log.Fatalf("Failed to configure kickoff scheduler: %v", err)
+		}
+		scheduler.Start()
+		defer scheduler.Stop()
+	}
+
 	// Setup Router
 	r := gin.Default()
 
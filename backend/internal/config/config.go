@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +13,59 @@ type Config struct {
 	MongoURI    string
 	MongoDBName string
 	JWTSecret   string
+
+	// Sessions
+	AccessTokenTTL  int // Minutes an access token is valid
+	RefreshTokenTTL int // Days a refresh token is valid; using it issues a new one
+
+	// Rate limits and login lockout
+	RateLimitStore        string // memory, mongo or off
+	LoginLockoutThreshold int    // Failed logins before an address is locked out
+	LoginLockoutBase      int    // Seconds of the first lockout; each further failure doubles it
+	LoginLockoutMax       int    // Longest lockout in minutes
+
+	// Two-factor authentication
+	MFARequiredRoles string // Comma-separated roles that must use a TOTP app to sign in; empty for the roles that manage users or matches, none for nobody
+
+	// Account emails
+	AppEnv       string // development logs the links in emails; anything else keeps them out of the log
+	AppURL       string // Frontend base URL the links in emails point to
+	MailDriver   string // smtp, file or log
+	MailFrom     string
+	MailDir      string // Where the file driver writes messages
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// Single sign-on with an OIDC provider; off while OIDCIssuer is empty
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string // The API's /auth/oidc/callback, as registered with the provider
+	OIDCScopes       string // Space-separated
+	OIDCProviderName string // Shown on the sign-in button
+
+	// Discipline rules
+	YellowCardThreshold      int // Yellows that trigger a one-match ban
+	YellowCardCutoffMatchday int // Yellows only count towards a ban up to this matchday
+	RedCardBanMatches        int
+
+	// Kickoff scheduler
+	SchedulerEnabled  bool
+	SchedulerInterval int     // Seconds between scans of the matches collection
+	SchedulerCatchUp  int     // Hours a match may be overdue and still start; older ones are left to an admin
+	SchedulerMaxStart int     // Most matches one scan starts
+	ClockStart        string  // RFC 3339 time the virtual clock starts at; empty runs on real time
+	ClockSpeed        float64 // Virtual seconds per real second
+
+	// SportMonks importer
+	SportMonksToken       string
+	SportMonksBaseURL     string
+	SportMonksSeasonID    string // SportMonks ID of the season to import
+	SportMonksMode        string // live, record or replay
+	SportMonksFixturesDir string // Where record mode writes responses and replay mode reads them
+	SportMonksRateLimit   int    // Requests per minute
 }
 
 func LoadConfig() *Config {
@@ -25,6 +79,51 @@ func LoadConfig() *Config {
 		MongoURI:    getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		MongoDBName: getEnv("MONGO_DB_NAME", "epl_db"),
 		JWTSecret:   getEnv("JWT_SECRET", "default_secret"),
+
+		AccessTokenTTL:  getEnvInt("ACCESS_TOKEN_TTL", 15),
+		RefreshTokenTTL: getEnvInt("REFRESH_TOKEN_TTL", 30),
+
+		RateLimitStore:        getEnv("RATE_LIMIT_STORE", "memory"),
+		LoginLockoutThreshold: getEnvInt("LOGIN_LOCKOUT_THRESHOLD", 5),
+		LoginLockoutBase:      getEnvInt("LOGIN_LOCKOUT_BASE", 30),
+		LoginLockoutMax:       getEnvInt("LOGIN_LOCKOUT_MAX", 60),
+
+		MFARequiredRoles: getEnv("MFA_REQUIRED_ROLES", ""),
+
+		AppEnv:       getEnv("APP_ENV", "production"),
+		AppURL:       getEnv("APP_URL", "http://localhost:5173"),
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		MailFrom:     getEnv("MAIL_FROM", "EPL <no-reply@epl.local>"),
+		MailDir:      getEnv("MAIL_DIR", "mail"),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnvInt("SMTP_PORT", 587),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		OIDCIssuer:       getEnv("OIDC_ISSUER", ""),
+		OIDCClientID:     getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:  getEnv("OIDC_REDIRECT_URL", getEnv("APP_URL", "http://localhost:5173")+"/api/auth/oidc/callback"),
+		OIDCScopes:       getEnv("OIDC_SCOPES", "openid email profile"),
+		OIDCProviderName: getEnv("OIDC_PROVIDER_NAME", "SSO"),
+
+		YellowCardThreshold:      getEnvInt("YELLOW_CARD_THRESHOLD", 5),
+		YellowCardCutoffMatchday: getEnvInt("YELLOW_CARD_CUTOFF_MATCHDAY", 19),
+		RedCardBanMatches:        getEnvInt("RED_CARD_BAN_MATCHES", 1),
+
+		SchedulerEnabled:  getEnv("SCHEDULER_ENABLED", "false") == "true",
+		SchedulerInterval: getEnvInt("SCHEDULER_INTERVAL", 15),
+		SchedulerCatchUp:  getEnvInt("SCHEDULER_CATCH_UP", 2),
+		SchedulerMaxStart: getEnvInt("SCHEDULER_MAX_STARTS", 10),
+		ClockStart:        getEnv("CLOCK_START", ""),
+		ClockSpeed:        getEnvFloat("CLOCK_SPEED", 1),
+
+		SportMonksToken:       getEnv("SPORTMONKS_API_TOKEN", ""),
+		SportMonksBaseURL:     getEnv("SPORTMONKS_BASE_URL", "https://api.sportmonks.com/v3/football"),
+		SportMonksSeasonID:    getEnv("SPORTMONKS_SEASON_ID", "25583"),
+		SportMonksMode:        getEnv("SPORTMONKS_MODE", "live"),
+		SportMonksFixturesDir: getEnv("SPORTMONKS_FIXTURES_DIR", "fixtures/sportmonks"),
+		SportMonksRateLimit:   getEnvInt("SPORTMONKS_RATE_LIMIT", 50),
 	}
 }
 
@@ -34,3 +133,29 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: %s=%q is not a number, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("Warning: %s=%q is not a number, using %g", key, value, fallback)
+		return fallback
+	}
+	return f
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	service *services.AnalyticsService
+}
+
+func NewAnalyticsHandler(service *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		service: service,
+	}
+}
+
+// GetScoringPatterns returns league-wide goal timing and game-state analytics.
+// An optional ?teamId= narrows it down to a single team, ?season= picks the season.
+func (h *AnalyticsHandler) GetScoringPatterns(c *gin.Context) {
+	patterns, err := h.service.GetScoringPatterns(c.Query("season"), c.Query("teamId"))
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, patterns)
+}
+
+func (h *AnalyticsHandler) GetTeamScoringPatterns(c *gin.Context) {
+	patterns, err := h.service.GetScoringPatterns(c.Query("season"), c.Param("id"))
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, patterns)
+}
+
+func (h *AnalyticsHandler) GetPartnerships(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	partnerships, err := h.service.GetPartnerships(c.Query("season"), c.Query("teamId"), limit)
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, partnerships)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+}
+
+func NewAPIKeyHandler() *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: services.NewAPIKeyService(),
+	}
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes"`                         // read (default) and/or match-ops
+	Days   int      `json:"days" binding:"omitempty,min=1"` // Default 90
+}
+
+// CreateAPIKey issues a key for the signed-in user. The key is in the response only.
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	key, secret, err := h.apiKeyService.CreateAPIKey(c.GetString("userID"), req.Name, req.Scopes, time.Duration(req.Days)*24*time.Hour)
+	if err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"apiKey": key, "key": secret})
+}
+
+// GetAPIKeys lists the signed-in user's keys
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyService.GetAPIKeys(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey revokes one of the signed-in user's keys
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	if err := h.apiKeyService.RevokeAPIKey(c.GetString("userID"), c.Param("id")); err != nil {
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+func apiKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidAPIKeyRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+func NewAuditHandler() *AuditHandler {
+	return &AuditHandler{
+		auditService: services.NewAuditService(),
+	}
+}
+
+// GetAuditLog lists staff changes, newest first.
+// Query: ?actor=<id or email>&action=&targetType=&targetId=&from=&to=&limit=
+// from and to are RFC 3339 times or dates; a date in to includes that whole day.
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	filter := repositories.AuditFilter{
+		Actor:      c.Query("actor"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("targetType"),
+		TargetID:   c.Query("targetId"),
+	}
+	var err error
+	if filter.From, err = parseAuditTime(c.Query("from"), false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from: " + err.Error()})
+		return
+	}
+	if filter.To, err = parseAuditTime(c.Query("to"), true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to: " + err.Error()})
+		return
+	}
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+
+	entries, err := h.auditService.GetAuditLog(filter, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// parseAuditTime reads an RFC 3339 time or a date. With endOfDay a date means the end
+// of that day, so ?to=2026-02-11 includes the 11th.
+func parseAuditTime(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
 	"github.com/gin-gonic/gin"
@@ -21,7 +24,6 @@ type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
 	FullName string `json:"fullName" binding:"required"`
-	Role     string `json:"role"`
 }
 
 type LoginRequest struct {
@@ -29,6 +31,38 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// client identifies the device a session is started or refreshed from
+func client(c *gin.Context) services.Client {
+	return services.Client{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
+// tokenResponse is the body of every response that signs a user in
+func tokenResponse(tokens *services.TokenPair) gin.H {
+	return gin.H{
+		"token":            tokens.AccessToken,
+		"expiresIn":        tokens.ExpiresIn,
+		"refreshToken":     tokens.RefreshToken,
+		"refreshExpiresAt": tokens.RefreshExpiresAt,
+	}
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -36,13 +70,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authService.Register(req.Email, req.Password, req.FullName, req.Role)
+	tokens, err := h.authService.Register(req.Email, req.Password, req.FullName, client(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token, "message": "Registration successful"})
+	res := tokenResponse(tokens)
+	res["message"] = "Registration successful"
+	c.JSON(http.StatusOK, res)
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -52,16 +88,123 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.authService.Login(req.Email, req.Password)
+	tokens, user, err := h.authService.Login(req.Email, req.Password, client(c))
+	if lockedOut(c, err) || mfaChallenge(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, signedIn(tokens, user))
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"user":  user,
-	})
+// lockedOut answers 429 with Retry-After while an address is locked out, and reports
+// whether err was a lockout
+func lockedOut(c *gin.Context, err error) bool {
+	var locked *services.AccountLockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter().Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	return true
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.authService.Refresh(req.RefreshToken, client(c))
+	if err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokenResponse(tokens))
+}
+
+// Logout ends the session of a refresh token
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.Logout(req.RefreshToken); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// LogoutAll ends every session of the signed-in user
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	n, err := h.authService.LogoutAll(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out of all sessions", "revoked": n})
+}
+
+// ForgotPassword mails a reset link if the address has an account. The response is the
+// same either way.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.ForgotPassword(req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "If the address has an account, a reset link is on its way"})
+}
+
+// ResetPassword sets a new password with the token from a reset link
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.ResetPassword(req.Token, req.Password); err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed, sign in again"})
+}
+
+// VerifyEmail confirms an address with the token from a verification link
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.VerifyEmail(req.Token); err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Email address verified"})
+}
+
+// ResendVerification mails the signed-in user a new verification link
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	if err := h.authService.SendVerificationEmail(c.GetString("userID")); err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
 }
 
 func (h *AuthHandler) GetFavorites(c *gin.Context) {
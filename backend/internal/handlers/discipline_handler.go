@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DisciplineHandler struct {
+	service *services.DisciplineService
+}
+
+func NewDisciplineHandler(service *services.DisciplineService) *DisciplineHandler {
+	return &DisciplineHandler{
+		service: service,
+	}
+}
+
+// GetDiscipline returns per-player card counts, suspension rules and active bans
+func (h *DisciplineHandler) GetDiscipline(c *gin.Context) {
+	table, err := h.service.GetDisciplineTable(c.Query("season"))
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, table)
+}
+
+func (h *DisciplineHandler) GetFairPlay(c *gin.Context) {
+	table, err := h.service.GetFairPlayTable(c.Query("season"))
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, table)
+}
+
+// RecordCard books a player during or after a match
+func (h *DisciplineHandler) RecordCard(c *gin.Context) {
+	var req struct {
+		PlayerID string           `json:"playerId" binding:"required"`
+		Type     models.EventType `json:"type" binding:"required"`
+		Minute   int              `json:"minute"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := h.service.RecordCard(c.Param("id"), req.PlayerID, req.Type, req.Minute)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match or player not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, event)
+}
@@ -1,15 +1,16 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type FootballHandler struct {
@@ -24,9 +25,9 @@ func NewFootballHandler() *FootballHandler {
 
 func (h *FootballHandler) GetMatches(c *gin.Context) {
 	fmt.Println("=== DEBUG GET MATCHES EXECUTED ===")
-	matches, err := h.service.GetAllMatches()
+	matches, err := h.service.GetAllMatches(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -43,9 +44,9 @@ func (h *FootballHandler) GetMatches(c *gin.Context) {
 }
 
 func (h *FootballHandler) GetStandings(c *gin.Context) {
-	standings, err := h.service.GetStandings()
+	standings, err := h.service.GetStandings(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, standings)
@@ -72,7 +73,11 @@ func (h *FootballHandler) GetTeamByID(c *gin.Context) {
 
 func (h *FootballHandler) GetTeamMatches(c *gin.Context) {
 	id := c.Param("id")
-	matches, err := h.service.GetTeamMatches(id)
+	matches, err := h.service.GetTeamMatches(id, c.Query("season"))
+	if errors.Is(err, services.ErrUnknownSeason) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Matches not found"})
 		return
@@ -108,19 +113,49 @@ func (h *FootballHandler) UpdateMatchStatus(c *gin.Context) {
 	id := c.Param("id")
 	var req struct {
 		Status models.MatchStatus `json:"status" binding:"required"`
+		Reason string             `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.service.UpdateMatchStatus(id, req.Status); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.UpdateMatchStatus(id, req.Status, req.Reason); err != nil {
+		c.JSON(matchStatusErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Match status updated"})
 }
 
+// RescheduleMatch moves a postponed or abandoned match to a new date, keeping its matchday
+func (h *FootballHandler) RescheduleMatch(c *gin.Context) {
+	var req struct {
+		Date time.Time `json:"date" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	match, err := h.service.RescheduleMatch(c.Param("id"), req.Date)
+	if err != nil {
+		c.JSON(matchStatusErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, match)
+}
+
+func matchStatusErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidStatusChange):
+		return http.StatusBadRequest
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (h *FootballHandler) GetTeamSquad(c *gin.Context) {
 	teamID := c.Param("id")
 	players, err := h.service.GetTeamSquad(teamID)
@@ -132,9 +167,9 @@ func (h *FootballHandler) GetTeamSquad(c *gin.Context) {
 }
 
 func (h *FootballHandler) GetPlayers(c *gin.Context) {
-	players, err := h.service.GetAllPlayers()
+	players, err := h.service.GetAllPlayers(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, players)
@@ -142,65 +177,100 @@ func (h *FootballHandler) GetPlayers(c *gin.Context) {
 
 func (h *FootballHandler) GetPlayerByID(c *gin.Context) {
 	playerID := c.Param("id")
-	player, err := h.service.GetPlayerByID(playerID)
+	player, err := h.service.GetPlayerByID(c.Query("season"), playerID)
 	if err != nil {
+		if errors.Is(err, services.ErrUnknownSeason) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
 		return
 	}
 	c.JSON(http.StatusOK, player)
 }
 
-func (h *FootballHandler) GetResultsJSON(c *gin.Context) {
-	c.File("../results.json")
+func (h *FootballHandler) GetPlayerProfile(c *gin.Context) {
+	playerID := c.Param("id")
+	profile, err := h.service.GetPlayerProfile(playerID, c.Query("season"))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		if errors.Is(err, services.ErrUnknownSeason) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, profile)
 }
 
-func (h *FootballHandler) GetNextMatchesJSON(c *gin.Context) {
-	c.File("../next_matches.json")
-}
+// ComparePlayers handles GET /players/compare?ids=a,b,c
+func (h *FootballHandler) ComparePlayers(c *gin.Context) {
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
 
-// Helper struct for parsing JSON files locally
-type MatchJSON struct {
-	Matchday      int    `json:"matchday"`
-	Date          string `json:"date"`
-	Time          string `json:"time"`
-	HomeTeam      string `json:"homeTeam"`
-	AwayTeam      string `json:"awayTeam"`
-	HomeScore     int    `json:"homeScore"`
-	AwayScore     int    `json:"awayScore"`
-	HalfTimeScore string `json:"halfTimeScore"`
+	comparison, err := h.service.ComparePlayers(ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidComparison):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, mongo.ErrNoDocuments):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
 }
 
-func (h *FootballHandler) GetLatestResults(c *gin.Context) {
-	matches, err := h.service.GetLatestResults()
+// GetResultsJSON returns all finished matches in the shape results.json used to have
+func (h *FootballHandler) GetResultsJSON(c *gin.Context) {
+	matches, err := h.service.GetResultsJSON(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch latest results"})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, matches)
 }
 
-func (h *FootballHandler) GetUpcomingFixtures(c *gin.Context) {
-	matches, err := h.service.GetUpcomingFixtures()
+// GetNextMatchesJSON returns all scheduled matches in the shape next_matches.json used to have
+func (h *FootballHandler) GetNextMatchesJSON(c *gin.Context) {
+	matches, err := h.service.GetNextMatchesJSON(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch upcoming fixtures"})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, matches)
 }
 
-func readMatchesFromFile(filename string) ([]MatchJSON, error) {
-	var matches []MatchJSON
-
-	bytes, err := os.ReadFile(filename)
+func (h *FootballHandler) GetLatestResults(c *gin.Context) {
+	matches, err := h.service.GetLatestResults(c.Query("season"))
+	if errors.Is(err, services.ErrUnknownSeason) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
-		return nil, err
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch latest results"})
+		return
 	}
+	c.JSON(http.StatusOK, matches)
+}
 
-	if err := json.Unmarshal(bytes, &matches); err != nil {
-		return nil, err
+func (h *FootballHandler) GetUpcomingFixtures(c *gin.Context) {
+	matches, err := h.service.GetUpcomingFixtures()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch upcoming fixtures"})
+		return
 	}
-
-	return matches, nil
+	c.JSON(http.StatusOK, matches)
 }
 
 // --- Match Lifecycle ---
@@ -223,6 +293,69 @@ func (h *FootballHandler) FinishMatch(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Match finished, standings updated"})
 }
 
+// UpdateTeamAliases replaces the alternative names and external source IDs of a team
+func (h *FootballHandler) UpdateTeamAliases(c *gin.Context) {
+	var req struct {
+		Aliases     []string          `json:"aliases"`
+		ExternalIDs map[string]string `json:"externalIds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	team, err := h.service.UpdateTeamAliases(c.Param("id"), req.Aliases, req.ExternalIDs)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+// SetLineup names a team's starting eleven for a scheduled match
+func (h *FootballHandler) SetLineup(c *gin.Context) {
+	var req struct {
+		TeamID    string   `json:"teamId" binding:"required"`
+		PlayerIDs []string `json:"playerIds" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.SetLineup(c.Param("id"), req.TeamID, req.PlayerIDs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Lineup saved"})
+}
+
+// RecordSubstitution brings a player on for another during or after a match
+func (h *FootballHandler) RecordSubstitution(c *gin.Context) {
+	var req struct {
+		PlayerID         string `json:"playerId" binding:"required"`
+		ReplacedPlayerID string `json:"replacedPlayerId" binding:"required"`
+		Minute           int    `json:"minute"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := h.service.RecordSubstitution(c.Param("id"), req.PlayerID, req.ReplacedPlayerID, req.Minute)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match or player not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, event)
+}
+
 // --- Event Management ---
 
 func (h *FootballHandler) GetMatchEventsByID(c *gin.Context) {
@@ -272,9 +405,9 @@ func (h *FootballHandler) GetMatchesByMatchday(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid matchday"})
 		return
 	}
-	matches, err := h.service.GetMatchesByMatchday(day)
+	matches, err := h.service.GetMatchesByMatchday(c.Query("season"), day)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, matches)
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CreateInviteRequest struct {
+	Email string `json:"email" binding:"omitempty,email"` // Only this address may accept, if set
+	Role  string `json:"role"`                            // Staff role to grant, default ADMIN
+	Hours int    `json:"hours" binding:"omitempty,min=1"` // Default 72
+}
+
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+	FullName string `json:"fullName"` // Required for a new account
+}
+
+type SetRoleRequest struct {
+	Role   string `json:"role" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// actor returns the signed-in user, as set by the auth middleware
+func actor(c *gin.Context) services.Actor {
+	return services.Actor{ID: c.GetString("userID"), Email: c.GetString("email")}
+}
+
+// CreateInvite issues a single-use staff invite. The token is in the response only.
+func (h *AuthHandler) CreateInvite(c *gin.Context) {
+	var req CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invite, token, err := h.authService.CreateInvite(actor(c), req.Email, req.Role, time.Duration(req.Hours)*time.Hour)
+	if err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"invite": invite, "token": token})
+}
+
+func (h *AuthHandler) GetInvites(c *gin.Context) {
+	invites, err := h.authService.GetPendingInvites()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, invites)
+}
+
+func (h *AuthHandler) RevokeInvite(c *gin.Context) {
+	if err := h.authService.RevokeInvite(c.Param("id")); err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Invite revoked"})
+}
+
+// AcceptInvite redeems an invite, creating the staff account or promoting an existing one
+func (h *AuthHandler) AcceptInvite(c *gin.Context) {
+	var req AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, user, err := h.authService.AcceptInvite(req.Token, req.Email, req.Password, req.FullName, client(c))
+	if lockedOut(c, err) || mfaChallenge(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, signedIn(tokens, user))
+}
+
+// SetRole gives a user another role
+func (h *AuthHandler) SetRole(c *gin.Context) {
+	var req SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.authService.SetRole(actor(c), c.Param("id"), req.Role, req.Reason)
+	if err != nil {
+		c.JSON(authErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// GetRoleChanges lists the role audit trail, newest first (?user= for one user, ?limit=, default 100)
+func (h *AuthHandler) GetRoleChanges(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	changes, err := h.authService.GetRoleChanges(c.Query("user"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+func authErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidInvite), errors.Is(err, services.ErrInvalidRole), errors.Is(err, services.ErrInvalidEmailToken):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidRefreshToken), errors.Is(err, services.ErrRefreshTokenReused):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrEmailAlreadyVerified):
+		return http.StatusConflict
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MFATokenRequest struct {
+	MFAToken string `json:"mfaToken" binding:"required"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfaToken" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP or recovery code
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableMFARequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// mfaChallenge answers a sign-in that still needs a second factor with its MFA token,
+// and reports whether err was one
+func mfaChallenge(c *gin.Context, err error) bool {
+	var challenge *services.MFAChallengeError
+	if !errors.As(err, &challenge) {
+		return false
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"mfaRequired":    true,
+		"mfaToken":       challenge.Token,
+		"expiresIn":      challenge.ExpiresIn,
+		"enrollRequired": challenge.Enroll,
+	})
+	return true
+}
+
+// signedIn answers a completed sign-in, the same way Login does
+func signedIn(tokens *services.TokenPair, user *models.User) gin.H {
+	res := tokenResponse(tokens)
+	res["user"] = user
+	res["permissions"] = models.RolePermissions(user.Role)
+	return res
+}
+
+// VerifyMFA completes a password or single sign-on sign-in with a TOTP or recovery code
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, user, err := h.authService.VerifyMFA(req.MFAToken, req.Code, client(c))
+	if lockedOut(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, signedIn(tokens, user))
+}
+
+// EnrollPendingMFA starts the enrolment a sign-in needs because the user's role requires
+// two-factor authentication
+func (h *AuthHandler) EnrollPendingMFA(c *gin.Context) {
+	var req MFATokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	enrolment, err := h.authService.EnrollMFAWithToken(req.MFAToken)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, enrolment)
+}
+
+// ConfirmPendingMFA confirms that enrolment with a first code and completes the sign-in
+func (h *AuthHandler) ConfirmPendingMFA(c *gin.Context) {
+	var req VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, tokens, user, err := h.authService.ConfirmMFAEnrolment(req.MFAToken, req.Code, client(c))
+	if lockedOut(c, err) {
+		return
+	}
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	res := signedIn(tokens, user)
+	res["recoveryCodes"] = codes
+	c.JSON(http.StatusOK, res)
+}
+
+// EnrollMFA gives the signed-in user a new TOTP secret to add to their authenticator app
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	enrolment, err := h.authService.EnrollMFA(c.GetString("userID"))
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, enrolment)
+}
+
+// ConfirmMFA turns two-factor authentication on with a first code, and returns the
+// recovery codes
+func (h *AuthHandler) ConfirmMFA(c *gin.Context) {
+	var req MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.authService.ConfirmMFA(c.GetString("userID"), req.Code)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication is on", "recoveryCodes": codes})
+}
+
+// DisableMFA turns two-factor authentication off
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	var req DisableMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.DisableMFA(c.GetString("userID"), req.Password, req.Code); err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication is off"})
+}
+
+// mfaErrorStatus maps two-factor errors. Wrong codes are 403, not 401, so the frontend
+// doesn't take them for an expired session.
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidMFAToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrInvalidMFACode), errors.Is(err, services.ErrMFAMandatory):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrMFANotEnrolled), errors.Is(err, services.ErrMFAAlreadyEnabled):
+		return http.StatusConflict
+	default:
+		return profileErrorStatus(err)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCConfig tells the sign-in page whether to offer single sign-on
+func (h *AuthHandler) OIDCConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"enabled": h.authService.OIDCEnabled(),
+		"name":    config.LoadConfig().OIDCProviderName,
+	})
+}
+
+// oidcStateCookie holds the state of the browser's sign-in. Without it, anyone could
+// send a victim to the callback with the attacker's own code and state, signing the
+// victim in to the attacker's account.
+const oidcStateCookie = "epl_oidc_state"
+
+// OIDCLogin sends the browser to the provider's sign-in page. ?redirect= is the app path
+// to return to afterwards.
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	target, state, err := h.authService.StartOIDCLogin(c.Query("redirect"))
+	if err != nil {
+		oidcFailed(c, err.Error())
+		return
+	}
+	setOIDCStateCookie(c, state, int(services.OIDCStateTTL.Seconds()))
+	c.Redirect(http.StatusFound, target)
+}
+
+// OIDCCallback is where the provider sends the browser back. It signs the user in and
+// returns to the app's /auth page with the tokens in the URL fragment, which browsers
+// don't send to servers, or with an MFA token or an error.
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	cookie, _ := c.Cookie(oidcStateCookie)
+	setOIDCStateCookie(c, "", -1)
+	if code := c.Query("error"); code != "" {
+		msg := "sign-in failed at the provider: " + code
+		if desc := c.Query("error_description"); desc != "" {
+			msg += " (" + desc + ")"
+		}
+		oidcFailed(c, msg)
+		return
+	}
+
+	// Only the browser that started the sign-in may finish it
+	state := c.Query("state")
+	if state == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+		oidcFailed(c, services.ErrInvalidOIDCState.Error())
+		return
+	}
+
+	tokens, user, redirect, err := h.authService.FinishOIDCLogin(state, c.Query("code"), client(c))
+	var challenge *services.MFAChallengeError
+	if errors.As(err, &challenge) {
+		appRedirect(c, url.Values{
+			"mfaToken":       {challenge.Token},
+			"enrollRequired": {strconv.FormatBool(challenge.Enroll)},
+			"redirect":       {redirect},
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("[Auth] Single sign-on failed: %v", err)
+		oidcFailed(c, err.Error())
+		return
+	}
+	log.Printf("[Auth] %s signed in with single sign-on", user.Email)
+	appRedirect(c, url.Values{
+		"token":        {tokens.AccessToken},
+		"refreshToken": {tokens.RefreshToken},
+		"redirect":     {redirect},
+	})
+}
+
+// setOIDCStateCookie sets the state cookie for the callback path only; maxAge -1 deletes it.
+// SameSite=Lax still sends it on the provider's top-level redirect back.
+func setOIDCStateCookie(c *gin.Context, state string, maxAge int) {
+	path := "/"
+	secure := c.Request.TLS != nil
+	if u, err := url.Parse(config.LoadConfig().OIDCRedirectURL); err == nil {
+		if u.Path != "" {
+			path = u.Path
+		}
+		secure = secure || u.Scheme == "https"
+	}
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     path,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func oidcFailed(c *gin.Context, msg string) {
+	appRedirect(c, url.Values{"error": {msg}})
+}
+
+// appRedirect sends the browser to the app's /auth page with fragment
+func appRedirect(c *gin.Context, fragment url.Values) {
+	base := strings.TrimRight(config.LoadConfig().AppURL, "/")
+	c.Redirect(http.StatusFound, base+"/auth#"+fragment.Encode())
+}
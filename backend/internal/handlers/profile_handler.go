@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateProfileRequest struct {
+	FullName *string `json:"fullName"`
+	Email    *string `json:"email" binding:"omitempty,email"`
+	Password string  `json:"password"` // Current password, required to change the email address
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required,min=6"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// GetMe returns the signed-in user and what their role allows
+func (h *AuthHandler) GetMe(c *gin.Context) {
+	user, err := h.authService.GetProfile(c.GetString("userID"))
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"user": user, "permissions": models.RolePermissions(user.Role)})
+}
+
+// UpdateMe changes the signed-in user's name or email address
+func (h *AuthHandler) UpdateMe(c *gin.Context) {
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.authService.UpdateProfile(c.GetString("userID"), services.ProfileUpdate{
+		FullName: req.FullName,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// ChangePassword sets a new password and signs out every other session
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.authService.ChangePassword(c.GetString("userID"), req.CurrentPassword, req.NewPassword, client(c))
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	res := tokenResponse(tokens)
+	res["message"] = "Password changed"
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteMe deletes the signed-in user's account
+func (h *AuthHandler) DeleteMe(c *gin.Context) {
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.DeleteAccount(c.GetString("userID"), req.Password); err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted"})
+}
+
+// profileErrorStatus is authErrorStatus for a signed-in user: a wrong password is 403,
+// since 401 would tell the client its session has ended.
+func profileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidCredentials):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrInvalidProfile):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrUserExists):
+		return http.StatusConflict
+	default:
+		return authErrorStatus(err)
+	}
+}
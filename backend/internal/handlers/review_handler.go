@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
@@ -32,8 +33,11 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 		return
 	}
 
-	email, _ := c.Get("email") // Injected by AuthMiddleware
-	err := h.reviewService.CreateReview(email.(string), req.Content, req.Rating, req.MatchID, req.TeamID, req.PlayerID)
+	err := h.reviewService.CreateReview(c.GetString("userID"), req.Content, req.Rating, req.MatchID, req.TeamID, req.PlayerID)
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Verify your email address before posting reviews"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
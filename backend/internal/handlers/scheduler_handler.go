@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type SchedulerHandler struct{}
+
+func NewSchedulerHandler() *SchedulerHandler {
+	return &SchedulerHandler{}
+}
+
+// GetQueue shows the kickoff scheduler's clock, the next kickoffs and the live matches
+func (h *SchedulerHandler) GetQueue(c *gin.Context) {
+	scheduler := services.GetKickoffScheduler()
+	if scheduler == nil {
+		c.JSON(http.StatusOK, services.DisabledSchedulerStatus())
+		return
+	}
+
+	status, err := scheduler.Status()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type SeasonHandler struct {
+	service *services.SeasonService
+}
+
+func NewSeasonHandler(service *services.SeasonService) *SeasonHandler {
+	return &SeasonHandler{
+		service: service,
+	}
+}
+
+func (h *SeasonHandler) GetSeasons(c *gin.Context) {
+	seasons, err := h.service.GetSeasons()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, seasons)
+}
+
+func (h *SeasonHandler) GetSeason(c *gin.Context) {
+	season, err := h.service.GetSeason(c.Param("id"))
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, season)
+}
+
+// Rollover archives the active season and starts the next one
+func (h *SeasonHandler) Rollover(c *gin.Context) {
+	var req services.RolloverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Rollover(req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRollover) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GenerateFixtures builds a double round-robin for a season (?season=, default active)
+func (h *SeasonHandler) GenerateFixtures(c *gin.Context) {
+	var req services.GenerateFixturesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.GenerateFixtures(c.Query("season"), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFixtures) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	status := http.StatusOK
+	if result.Saved {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+// seasonErrorStatus maps an error from a season-scoped lookup to a status code:
+// an unknown ?season= is a 404, anything else a 500
+func seasonErrorStatus(err error) int {
+	if errors.Is(err, services.ErrUnknownSeason) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type StatsHandler struct {
@@ -19,9 +23,9 @@ func NewStatsHandler(service *services.StatsService) *StatsHandler {
 }
 
 func (h *StatsHandler) GetStats(c *gin.Context) {
-	stats, err := h.service.GetStats()
+	stats, err := h.service.GetStats(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, stats)
@@ -43,28 +47,91 @@ func (h *StatsHandler) GetMatchEvents(c *gin.Context) {
 }
 
 func (h *StatsHandler) GetTopScorers(c *gin.Context) {
-	stats, err := h.service.GetStats()
+	stats, err := h.service.GetStats(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, stats.TopScorers)
 }
 
 func (h *StatsHandler) GetTopAssists(c *gin.Context) {
-	stats, err := h.service.GetStats()
+	stats, err := h.service.GetStats(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, stats.TopAssists)
 }
 
 func (h *StatsHandler) GetCleanSheets(c *gin.Context) {
-	stats, err := h.service.GetStats()
+	stats, err := h.service.GetStats(c.Query("season"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, stats.CleanSheets)
 }
+
+func (h *StatsHandler) GetTeamXG(c *gin.Context) {
+	table, err := h.service.GetTeamXG(c.Query("season"))
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, table)
+}
+
+// GetPlayerXG returns the top players by xG, with optional ?season=, ?teamId= and ?limit= (default 20)
+func (h *StatsHandler) GetPlayerXG(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	players, err := h.service.GetPlayerXG(c.Query("season"), c.Query("teamId"), limit)
+	if err != nil {
+		c.JSON(seasonErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, players)
+}
+
+func (h *StatsHandler) GetMatchXG(c *gin.Context) {
+	result, err := h.service.GetMatchXG(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// RecordShot adds a shot to a match; its xG is computed from the zone and shot type
+func (h *StatsHandler) RecordShot(c *gin.Context) {
+	var req struct {
+		PlayerID string          `json:"playerId" binding:"required"`
+		Zone     models.ShotZone `json:"zone"`
+		Type     models.ShotType `json:"type" binding:"required"`
+		Minute   int             `json:"minute"`
+		IsGoal   bool            `json:"isGoal"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shot, err := h.service.RecordShot(c.Param("id"), req.PlayerID, req.Zone, req.Type, req.Minute, req.IsGoal)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Match or player not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, shot)
+}
@@ -0,0 +1,154 @@
+// Package mailer sends the account emails (verification links, password resets). SMTP
+// delivers them for real; the file mailer logs each message and can write it to disk, for
+// local development. Package mailertest has a mailer for tests.
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends messages
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Options configures New
+type Options struct {
+	Driver   string // smtp, file or log; defaults to log
+	From     string
+	Host     string // SMTP
+	Port     int
+	Username string // Empty to send without authentication
+	Password string
+	Dir      string // File mailer
+	LogBody  bool   // File and log mailers log the whole message, links included; development only
+}
+
+// New returns the mailer opts.Driver names
+func New(opts Options) (Mailer, error) {
+	switch opts.Driver {
+	case "smtp":
+		if opts.Host == "" || opts.From == "" {
+			return nil, errors.New("mailer: smtp needs a host and a from address")
+		}
+		port := opts.Port
+		if port == 0 {
+			port = 587
+		}
+		return &SMTPMailer{
+			addr:     net.JoinHostPort(opts.Host, strconv.Itoa(port)),
+			host:     opts.Host,
+			from:     opts.From,
+			username: opts.Username,
+			password: opts.Password,
+		}, nil
+	case "file":
+		if opts.Dir == "" {
+			return nil, errors.New("mailer: file needs a directory")
+		}
+		return &FileMailer{From: opts.From, Dir: opts.Dir, LogBody: opts.LogBody}, nil
+	case "log", "":
+		return &FileMailer{From: opts.From, LogBody: opts.LogBody}, nil
+	default:
+		return nil, fmt.Errorf("mailer: unknown driver %q (use smtp, file or log)", opts.Driver)
+	}
+}
+
+// SMTPMailer sends through an SMTP server, with STARTTLS when the server offers it
+type SMTPMailer struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	sender := m.from
+	if addr, err := mail.ParseAddress(m.from); err == nil {
+		sender = addr.Address // "EPL <no-reply@...>" has the envelope address no-reply@...
+	}
+	if err := smtp.SendMail(m.addr, auth, sender, []string{msg.To}, format(m.from, msg)); err != nil {
+		return fmt.Errorf("mailer: send to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// FileMailer delivers nothing: it logs every message and, if Dir is set, writes it there
+// as an .eml file. Bodies carry live links, so the log only has them when LogBody is set.
+type FileMailer struct {
+	From    string
+	Dir     string
+	LogBody bool
+
+	mu sync.Mutex
+	n  int // Messages sent, to keep file names apart
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	m.mu.Lock()
+	m.n++
+	n := m.n
+	m.mu.Unlock()
+
+	if m.LogBody {
+		log.Printf("[Mailer] To %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	} else {
+		log.Printf("[Mailer] To %s: %s (body not logged outside development)", msg.To, msg.Subject)
+	}
+	if m.Dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return fmt.Errorf("mailer: %w", err)
+	}
+	name := fmt.Sprintf("%s-%03d-%s.eml", time.Now().Format("20060102-150405"), n, sanitize(msg.To))
+	if err := os.WriteFile(filepath.Join(m.Dir, name), format(m.From, msg), 0o644); err != nil {
+		return fmt.Errorf("mailer: %w", err)
+	}
+	return nil
+}
+
+// format renders msg as an RFC 5322 message
+func format(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, s)
+}
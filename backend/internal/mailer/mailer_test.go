@@ -0,0 +1,97 @@
+package mailer
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureLog sends the standard logger to a buffer for the rest of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	out, flags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+	})
+	return &buf
+}
+
+var reset = Message{
+	To:      "fan@example.com",
+	Subject: "Reset your password",
+	Body:    "Choose a new password:\nhttp://localhost:5173/reset-password?token=secret-token\n",
+}
+
+func TestFileMailerKeepsBodiesOutOfTheLog(t *testing.T) {
+	buf := captureLog(t)
+	m, err := New(Options{Driver: "log"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := m.Send(reset); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Errorf("the log has the reset link: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), reset.Subject) {
+		t.Errorf("the log should still say what was sent: %q", buf.String())
+	}
+
+	buf.Reset()
+	m, _ = New(Options{Driver: "log", LogBody: true})
+	if err := m.Send(reset); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if !strings.Contains(buf.String(), "secret-token") {
+		t.Errorf("LogBody should log the link: %q", buf.String())
+	}
+}
+
+func TestFileMailerWritesMessages(t *testing.T) {
+	captureLog(t)
+	dir := t.TempDir()
+	m, err := New(Options{Driver: "file", From: "EPL <no-reply@epl.local>", Dir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := m.Send(reset); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("got %v (%v), want 2 .eml files", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"From: EPL <no-reply@epl.local>\r\n", "To: fan@example.com\r\n", "token=secret-token\r\n"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("message lacks %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	for _, opts := range []Options{
+		{Driver: "smtp", From: "no-reply@epl.local"},
+		{Driver: "smtp", Host: "smtp.example.com"},
+		{Driver: "file"},
+		{Driver: "carrier-pigeon"},
+	} {
+		if _, err := New(opts); err == nil {
+			t.Errorf("New(%+v) should fail", opts)
+		}
+	}
+}
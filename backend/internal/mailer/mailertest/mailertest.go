@@ -0,0 +1,28 @@
+// Package mailertest has a mailer that keeps what it is sent, for tests
+package mailertest
+
+import (
+	"sync"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/mailer"
+)
+
+// Mailer keeps every message in memory and delivers nothing
+type Mailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+}
+
+func (m *Mailer) Send(msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns the messages sent so far
+func (m *Mailer) Sent() []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]mailer.Message(nil), m.sent...)
+}
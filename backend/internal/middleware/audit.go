@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxAuditBody is the largest request body kept in an audit entry
+const maxAuditBody = 64 << 10
+
+// AuditTarget is the kind of document a route changes
+type AuditTarget struct {
+	Type       string // e.g. "player"
+	Collection string
+	Param      string // Route parameter with the document's _id; empty if the route has none
+}
+
+// Audit records a successful request in the audit log: who made it, the target, the
+// fields of the target that changed, and the request body. It runs after AuthMiddleware.
+func Audit(action string, target AuditTarget) gin.HandlerFunc {
+	audit := services.NewAuditService()
+
+	return func(c *gin.Context) {
+		var id string
+		if target.Param != "" {
+			id = c.Param(target.Param)
+		}
+		var before bson.M
+		if id != "" {
+			before = audit.Snapshot(target.Collection, id)
+		}
+		var request map[string]interface{}
+		if body := peekBody(c); len(body) > 0 && len(body) <= maxAuditBody {
+			_ = json.Unmarshal(body, &request) // Non-JSON bodies aren't kept
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status >= http.StatusBadRequest {
+			return // Nothing changed
+		}
+		var after bson.M
+		if id != "" {
+			after = audit.Snapshot(target.Collection, id)
+		}
+		audit.Record(&models.AuditEntry{
+			ActorID:    c.GetString("userID"),
+			ActorEmail: c.GetString("email"),
+			ActorRole:  c.GetString("role"),
+			APIKeyID:   c.GetString("apiKeyID"),
+			Action:     action,
+			TargetType: target.Type,
+			TargetID:   id,
+			Request:    request,
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.Path,
+			Status:     status,
+			IP:         c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+		}, before, after)
+	}
+}
+
+// peekBody reads the request body and puts it back for the handler
+func peekBody(c *gin.Context) []byte {
+	if c.Request.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	return body
+}
@@ -1,16 +1,27 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AuthMiddleware accepts a Bearer access token or an X-API-Key header. API keys are
+// read-only unless the route group allows writes with AllowAPIKeyWrites.
 func AuthMiddleware() gin.HandlerFunc {
+	apiKeys := services.NewAPIKeyService()
+
 	return func(c *gin.Context) {
+		if secret := c.GetHeader("X-API-Key"); secret != "" {
+			authenticateAPIKey(c, apiKeys, secret)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
@@ -47,7 +58,53 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Handlers and RequirePermission read the claims from the context
+		email, _ := claims["email"].(string)
+		role, _ := claims["role"].(string)
 		c.Set("userID", userID)
+		c.Set("email", email)
+		c.Set("role", role)
+		c.Next()
+	}
+}
+
+// AllowAPIKeyWrites lets API keys make changes in a route group. It goes before
+// AuthMiddleware, and every route in the group must check a permission with
+// RequirePermission, which also checks the key's scopes.
+func AllowAPIKeyWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("apiKeyWrites", true)
 		c.Next()
 	}
 }
+
+func authenticateAPIKey(c *gin.Context, apiKeys *services.APIKeyService, secret string) {
+	key, user, err := apiKeys.Authenticate(secret, c.ClientIP())
+	if errors.Is(err, services.ErrAPIKeyNeedsMFA) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		c.Abort()
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Abort()
+		return
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if !c.GetBool("apiKeyWrites") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API keys can't make this change; sign in instead"})
+			c.Abort()
+			return
+		}
+	}
+
+	c.Set("userID", user.ID.Hex())
+	c.Set("email", user.Email)
+	c.Set("role", user.Role)
+	c.Set("apiKeyID", key.ID.Hex())
+	c.Set("apiKeyScopes", key.Scopes)
+	c.Next()
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// limitedRouter serves /limited behind RateLimit and echoes the request body back
+func limitedRouter(store ratelimit.Store, rule RateRule) *gin.Engine {
+	r := gin.New()
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	r.Any("/limited", RateLimit(store, rule), echo)
+	return r
+}
+
+func send(r http.Handler, method, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/limited", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitAnswers429(t *testing.T) {
+	r := limitedRouter(ratelimit.NewMemoryStore(), RateRule{Name: "test", Requests: 2, Window: time.Hour})
+
+	for i := 2; i > 0; i-- {
+		w := send(r, http.MethodGet, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("got %d within the limit, want 200", w.Code)
+		}
+		if got := w.Header().Get("X-RateLimit-Remaining"); got != strconv.Itoa(i-1) {
+			t.Errorf("got X-RateLimit-Remaining %s, want %d", got, i-1)
+		}
+	}
+
+	w := send(r, http.MethodGet, "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("got %d over the limit, want 429", w.Code)
+	}
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > int(time.Hour.Seconds()) {
+		t.Errorf("got Retry-After %q, want the seconds until the window ends", w.Header().Get("Retry-After"))
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("got X-RateLimit-Remaining %s, want 0", got)
+	}
+}
+
+func TestRateLimitMethods(t *testing.T) {
+	r := limitedRouter(ratelimit.NewMemoryStore(), RateRule{Name: "test", Requests: 1, Window: time.Hour, Methods: []string{http.MethodPost}})
+
+	for i := 0; i < 3; i++ {
+		if w := send(r, http.MethodGet, ""); w.Code != http.StatusOK {
+			t.Fatalf("GET %d: got %d, want 200 as only POST is limited", i+1, w.Code)
+		}
+	}
+	if w := send(r, http.MethodPost, "{}"); w.Code != http.StatusOK {
+		t.Fatalf("first POST: got %d, want 200", w.Code)
+	}
+	if w := send(r, http.MethodPost, "{}"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second POST: got %d, want 429", w.Code)
+	}
+}
+
+func TestRateLimitByAccount(t *testing.T) {
+	r := limitedRouter(ratelimit.NewMemoryStore(), RateRule{Name: "login", Requests: 1, Window: time.Hour, Key: ByAccount})
+
+	body := `{"email": "Fan@Example.com", "password": "secret"}`
+	w := send(r, http.MethodPost, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", w.Code)
+	}
+	// Peeking at the email leaves the body for the handler
+	if w.Body.String() != body {
+		t.Errorf("the handler read %q, want %q", w.Body.String(), body)
+	}
+
+	// The address counts however it is written
+	if w := send(r, http.MethodPost, `{"email": " fan@example.com"}`); w.Code != http.StatusTooManyRequests {
+		t.Errorf("same address: got %d, want 429", w.Code)
+	}
+	if w := send(r, http.MethodPost, `{"email": "other@example.com"}`); w.Code != http.StatusOK {
+		t.Errorf("another address: got %d, want 200", w.Code)
+	}
+
+	// Without an account there is nothing to count
+	for _, body := range []string{"", "not json", `{"password": "secret"}`} {
+		for i := 0; i < 2; i++ {
+			if w := send(r, http.MethodPost, body); w.Code != http.StatusOK {
+				t.Errorf("body %q: got %d, want 200", body, w.Code)
+			}
+		}
+	}
+}
+
+func TestByAccountPrefersSignedInUser(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email": "fan@example.com"}`))
+	c.Set("userID", "u1")
+	if got := ByAccount(c); got != "user:u1" {
+		t.Errorf("got key %q, want user:u1", got)
+	}
+}
+
+// failingStore is a store whose database is down
+type failingStore struct{}
+
+func (failingStore) Hit(string, time.Duration) (int, time.Time, error) {
+	return 0, time.Time{}, errors.New("store unavailable")
+}
+
+func TestRateLimitLetsThroughWithoutStore(t *testing.T) {
+	rule := RateRule{Name: "test", Requests: 1, Window: time.Hour}
+	for name, store := range map[string]ratelimit.Store{"no store": nil, "failing store": failingStore{}} {
+		r := limitedRouter(store, rule)
+		for i := 0; i < 3; i++ {
+			if w := send(r, http.MethodGet, ""); w.Code != http.StatusOK {
+				t.Errorf("%s: request %d got %d, want 200", name, i+1, w.Code)
+			}
+		}
+	}
+}
@@ -2,56 +2,45 @@ package middleware
 
 import (
 	"net/http"
-	"strings"
 
-	"github.com/Sanat-07/English-Premier-League/backend/internal/utils"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func AdminMiddleware() gin.HandlerFunc {
+// RequirePermission lets a request through only if the user's role grants perm.
+// It runs after AuthMiddleware and reads the role from the claims it stored.
+func RequirePermission(perm models.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		userID := c.GetString("userID")
+		if userID == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization required"})
 			c.Abort()
 			return
 		}
 
-		// Token is already validated by AuthMiddleware generally,
-		// but we parse it again to check the role or rely on Context if we stored it there.
-		// For robustness, let's re-parse or better yet, assume AuthMiddleware ran first sets context?
-		// Let's keep it simple and independent or chain them.
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid header"})
-			c.Abort()
-			return
-		}
-
-		tokenString := parts[1]
-		token, err := utils.ValidateToken(tokenString)
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		if !models.RoleHas(c.GetString("role"), perm) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Permission required: " + string(perm)})
 			c.Abort()
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid claims"})
+		// An API key can do no more than its scopes allow
+		if scopes, ok := c.Get("apiKeyScopes"); ok && !models.ScopesHave(scopes.([]string), perm) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API key scope required for: " + string(perm)})
 			c.Abort()
 			return
 		}
 
-		role, ok := claims["role"].(string)
-		if !ok || role != "ADMIN" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+		// Tokens outlive role changes: check the user's current role still allows it
+		user, err := repositories.NewUserRepository().GetUserByID(userID)
+		if err != nil || !models.RoleHas(user.Role, perm) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Permission required: " + string(perm)})
 			c.Abort()
 			return
 		}
 
+		c.Set("role", user.Role)
 		c.Next()
 	}
 }
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// API key scopes. A key can never do more than its owner's role allows; its scopes
+// narrow that further.
+const (
+	ScopeRead     = "read"      // GET requests only
+	ScopeMatchOps = "match-ops" // Also match management, for a role with matches:manage
+)
+
+var scopePermissions = map[string][]Permission{
+	ScopeRead:     nil,
+	ScopeMatchOps: {PermManageMatches},
+}
+
+// APIKey lets a script call the API as its owner through the X-API-Key header. Only a
+// hash of the key is stored; Prefix is kept so the owner can tell keys apart.
+type APIKey struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name       string             `bson:"name" json:"name"`
+	Prefix     string             `bson:"prefix" json:"prefix"`
+	KeyHash    string             `bson:"keyHash" json:"-"`
+	UserID     string             `bson:"userId" json:"userId"`
+	Scopes     []string           `bson:"scopes" json:"scopes"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+	LastUsedAt *time.Time         `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	LastUsedIP string             `bson:"lastUsedIp,omitempty" json:"lastUsedIp,omitempty"`
+	RevokedAt  *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+}
+
+// ValidScope reports whether scope is one of the scopes above
+func ValidScope(scope string) bool {
+	_, ok := scopePermissions[scope]
+	return ok
+}
+
+// ScopesHave reports whether any of scopes grants perm
+func ScopesHave(scopes []string, perm Permission) bool {
+	for _, scope := range scopes {
+		for _, p := range scopePermissions[scope] {
+			if p == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditEntry records a change a staff member made through the API
+type AuditEntry struct {
+	ID         primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	ActorID    string                 `bson:"actorId" json:"actorId"`
+	ActorEmail string                 `bson:"actorEmail" json:"actorEmail"`
+	ActorRole  string                 `bson:"actorRole" json:"actorRole"`
+	APIKeyID   string                 `bson:"apiKeyId,omitempty" json:"apiKeyId,omitempty"` // Set if the change was made with an API key
+	Action     string                 `bson:"action" json:"action"`                         // e.g. "player.delete"
+	TargetType string                 `bson:"targetType" json:"targetType"`
+	TargetID   string                 `bson:"targetId,omitempty" json:"targetId,omitempty"`
+	Changes    map[string]AuditChange `bson:"changes,omitempty" json:"changes,omitempty"` // Fields of the target that changed
+	Request    map[string]interface{} `bson:"request,omitempty" json:"request,omitempty"` // JSON body of the request
+	Method     string                 `bson:"method" json:"method"`
+	Path       string                 `bson:"path" json:"path"`
+	Status     int                    `bson:"status" json:"status"`
+	IP         string                 `bson:"ip" json:"ip"`
+	UserAgent  string                 `bson:"userAgent,omitempty" json:"userAgent,omitempty"`
+	CreatedAt  time.Time              `bson:"createdAt" json:"createdAt"`
+}
+
+// AuditChange is one field's value before and after a change; nil if it didn't exist
+type AuditChange struct {
+	From interface{} `bson:"from" json:"from"`
+	To   interface{} `bson:"to" json:"to"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -13,6 +14,10 @@ type Team struct {
 	Coach     string   `bson:"coach,omitempty" json:"coach,omitempty"`
 	LogoURL   string   `bson:"logoUrl" json:"logoUrl"`
 	Players   []Player `bson:"players,omitempty" json:"players,omitempty"`
+	// Other names the team goes by in data sources, e.g. "Wolves", "Man Utd"
+	Aliases []string `bson:"aliases,omitempty" json:"aliases,omitempty"`
+	// The team's ID in each external source, e.g. {"sportmonks": "29"}
+	ExternalIDs map[string]string `bson:"externalIds,omitempty" json:"externalIds,omitempty"`
 }
 
 type Player struct {
@@ -35,6 +40,10 @@ type Player struct {
 	ImagePath        string      `bson:"imagePath" json:"imagePath"`
 	IsCaptain        bool        `bson:"isCaptain" json:"isCaptain"`
 	Statistics       PlayerStats `bson:"statistics,omitempty" json:"statistics,omitempty"`
+
+	// Set when Statistics has been replaced by one season's numbers for an API response
+	SeasonID         string       `bson:"-" json:"seasonId,omitempty"`
+	CareerStatistics *PlayerStats `bson:"-" json:"careerStatistics,omitempty"`
 }
 
 type PlayerStats struct {
@@ -49,8 +58,19 @@ const (
 	MatchScheduled MatchStatus = "SCHEDULED"
 	MatchFinished  MatchStatus = "FINISHED"
 	MatchLive      MatchStatus = "LIVE"
+	MatchPostponed MatchStatus = "POSTPONED" // Waiting for a new date
+	MatchAbandoned MatchStatus = "ABANDONED" // Stopped during play; replayed from 0-0 once rescheduled
+	MatchCancelled MatchStatus = "CANCELLED" // Will not be played
 )
 
+func (s MatchStatus) Valid() bool {
+	switch s {
+	case MatchScheduled, MatchFinished, MatchLive, MatchPostponed, MatchAbandoned, MatchCancelled:
+		return true
+	}
+	return false
+}
+
 type Match struct {
 	ID         string       `bson:"_id" json:"id"`
 	HomeTeamID string       `bson:"homeTeamId" json:"homeTeamId"`
@@ -59,11 +79,23 @@ type Match struct {
 	AwayTeam   Team         `bson:"awayTeam,omitempty" json:"awayTeam,omitempty"`
 	HomeScore  int          `bson:"homeScore" json:"homeScore"`
 	AwayScore  int          `bson:"awayScore" json:"awayScore"`
+	HalfTime   string       `bson:"halfTimeScore,omitempty" json:"halfTimeScore,omitempty"` // e.g. "1-0"
 	Date       time.Time    `bson:"date" json:"date"`
 	Matchday   int          `bson:"matchday" json:"matchday"`
 	Status     MatchStatus  `bson:"status" json:"status"`
 	SeasonID   string       `bson:"seasonId" json:"seasonId"`
 	Events     []MatchEvent `bson:"events,omitempty" json:"events,omitempty"`
+	HomeLineup []string     `bson:"homeLineup,omitempty" json:"homeLineup,omitempty"` // Player IDs
+	AwayLineup []string     `bson:"awayLineup,omitempty" json:"awayLineup,omitempty"`
+	// Set the first time the match is moved; the match keeps its original matchday
+	OriginalDate *time.Time `bson:"originalDate,omitempty" json:"originalDate,omitempty"`
+	StatusReason string     `bson:"statusReason,omitempty" json:"statusReason,omitempty"` // e.g. "Waterlogged pitch"
+	StartedAt    *time.Time `bson:"startedAt,omitempty" json:"startedAt,omitempty"`       // Real time the match went LIVE
+}
+
+// Rescheduled reports whether the match has been moved away from its matchday's date
+func (m *Match) Rescheduled() bool {
+	return m.OriginalDate != nil
 }
 
 type EventType string
@@ -73,14 +105,79 @@ const (
 	YellowCard   EventType = "YELLOW_CARD"
 	RedCard      EventType = "RED_CARD"
 	Substitution EventType = "SUBSTITUTION"
+	Shot         EventType = "SHOT"
+)
+
+// ShotZone is where on the pitch a shot was taken from
+type ShotZone string
+
+const (
+	ZoneSixYardBox  ShotZone = "SIX_YARD_BOX"
+	ZoneCentralBox  ShotZone = "CENTRAL_BOX" // Inside the penalty area, in front of goal
+	ZoneWideBox     ShotZone = "WIDE_BOX"    // Inside the penalty area, tight angle
+	ZoneOutsideBox  ShotZone = "OUTSIDE_BOX"
+	ZonePenaltySpot ShotZone = "PENALTY_SPOT"
+)
+
+type ShotType string
+
+const (
+	ShotFoot     ShotType = "FOOT"
+	ShotHeader   ShotType = "HEADER"
+	ShotFreeKick ShotType = "FREE_KICK"
+	ShotPenalty  ShotType = "PENALTY"
 )
 
 type MatchEvent struct {
-	ID       string    `bson:"_id" json:"id"`
-	MatchID  string    `bson:"matchId" json:"matchId"`
-	PlayerID string    `bson:"playerId" json:"playerId"`
-	Type     EventType `bson:"type" json:"type"`
-	Minute   int       `bson:"minute" json:"minute"`
+	ID         string    `bson:"_id" json:"id"`
+	MatchID    string    `bson:"matchId" json:"matchId"`
+	PlayerID   string    `bson:"playerId" json:"playerId"`
+	PlayerName string    `bson:"playerName,omitempty" json:"playerName,omitempty"`
+	TeamID     string    `bson:"teamId,omitempty" json:"teamId,omitempty"`
+	SeasonID   string    `bson:"seasonId,omitempty" json:"seasonId,omitempty"`
+	Matchday   int       `bson:"matchday,omitempty" json:"matchday,omitempty"`
+	Type       EventType `bson:"type" json:"type"`
+	Minute     int       `bson:"minute" json:"minute"`
+
+	// Player going off, only set when Type is SUBSTITUTION; PlayerID is the one coming on
+	ReplacedPlayerID string `bson:"replacedPlayerId,omitempty" json:"replacedPlayerId,omitempty"`
+
+	// Shot details, only set when Type is SHOT
+	ShotZone ShotZone `bson:"shotZone,omitempty" json:"shotZone,omitempty"`
+	ShotType ShotType `bson:"shotType,omitempty" json:"shotType,omitempty"`
+	XG       float64  `bson:"xg,omitempty" json:"xg,omitempty"`
+	IsGoal   bool     `bson:"isGoal,omitempty" json:"isGoal,omitempty"`
+}
+
+type SuspensionReason string
+
+const (
+	SuspensionYellowAccumulation SuspensionReason = "YELLOW_ACCUMULATION"
+	SuspensionRedCard            SuspensionReason = "RED_CARD"
+)
+
+// Suspension is a ban served over a team's next Matches fixtures of the same season
+type Suspension struct {
+	ID              string           `bson:"_id" json:"id"`
+	PlayerID        string           `bson:"playerId" json:"playerId"`
+	PlayerName      string           `bson:"playerName" json:"playerName"`
+	TeamID          string           `bson:"teamId" json:"teamId"`
+	SeasonID        string           `bson:"seasonId" json:"seasonId"`
+	Reason          SuspensionReason `bson:"reason" json:"reason"`
+	TriggerMatchID  string           `bson:"triggerMatchId" json:"triggerMatchId"`
+	TriggerMatchday int              `bson:"triggerMatchday" json:"triggerMatchday"`
+	TriggerDate     time.Time        `bson:"triggerDate" json:"triggerDate"`
+	Matches         int              `bson:"matches" json:"matches"`
+	ServedMatchIDs  []string         `bson:"servedMatchIds" json:"servedMatchIds"`
+	CreatedAt       time.Time        `bson:"createdAt" json:"createdAt"`
+}
+
+// Remaining returns how many matches of the ban are still to be served
+func (s Suspension) Remaining() int {
+	if r := s.Matches - len(s.ServedMatchIDs); r > 0 {
+		return r
+	}
+	return 0
 }
 
 type GoalEvent struct {
@@ -98,16 +195,49 @@ type GoalEvent struct {
 	TeamID     string `bson:"teamId" json:"teamId"`
 	Minute     int    `bson:"minute" json:"minute"`
 	IsHomeGoal bool   `bson:"isHomeGoal" json:"isHomeGoal"`
+	SeasonID   string `bson:"seasonId,omitempty" json:"seasonId,omitempty"`
 }
 
 type Season struct {
-	ID       string `bson:"_id" json:"id"`
-	Year     string `bson:"year" json:"year"`
-	IsActive bool   `bson:"isActive" json:"isActive"`
+	ID        string    `bson:"_id" json:"id"`    // e.g. "2025-26"
+	Name      string    `bson:"name" json:"name"` // e.g. "Premier League 2025/26"
+	Year      string    `bson:"year" json:"year"` // e.g. "2025/26"
+	IsActive  bool      `bson:"isActive" json:"isActive"`
+	StartDate time.Time `bson:"startDate,omitempty" json:"startDate,omitempty"`
+	EndDate   time.Time `bson:"endDate,omitempty" json:"endDate,omitempty"`
+	TeamIDs   []string  `bson:"teamIds,omitempty" json:"teamIds,omitempty"`
+
+	// Filled in when the season is rolled over
+	FinalTable []Standing `bson:"finalTable,omitempty" json:"finalTable,omitempty"`
+	Relegated  []string   `bson:"relegatedTeamIds,omitempty" json:"relegatedTeamIds,omitempty"`
+	Promoted   []string   `bson:"promotedTeamIds,omitempty" json:"promotedTeamIds,omitempty"` // Clubs that came up into the next season
+	ArchivedAt time.Time  `bson:"archivedAt,omitempty" json:"archivedAt,omitempty"`
+}
+
+// NormalizeSeasonID turns "2025/26" into the canonical "2025-26"
+func NormalizeSeasonID(id string) string {
+	return strings.ReplaceAll(strings.TrimSpace(id), "/", "-")
+}
+
+// SeasonScopedID builds the _id of a document kept per season, e.g. a team's standing
+func SeasonScopedID(seasonID, id string) string {
+	return seasonID + ":" + id
+}
+
+// PlayerSeasonStats holds a player's statistics for one season;
+// Player.Statistics keeps the career totals
+type PlayerSeasonStats struct {
+	ID          string `bson:"_id" json:"-"`
+	SeasonID    string `bson:"seasonId" json:"seasonId"`
+	PlayerID    string `bson:"playerId" json:"playerId"`
+	TeamID      string `bson:"teamId" json:"teamId"`
+	PlayerStats `bson:",inline"`
 }
 
 type Standing struct {
-	TeamID           string   `bson:"_id" json:"teamId"`
+	ID               string   `bson:"_id" json:"-"` // SeasonScopedID(SeasonID, TeamID)
+	TeamID           string   `bson:"teamId" json:"teamId"`
+	SeasonID         string   `bson:"seasonId" json:"seasonId"`
 	Team             Team     `bson:"team,omitempty" json:"team,omitempty"`
 	Played           int      `bson:"played" json:"played"`
 	Wins             int      `bson:"wins" json:"wins"`
@@ -121,4 +251,5 @@ type Standing struct {
 	NextOpponentLogo string   `bson:"nextOpponentLogo" json:"nextOpponentLogo"`
 	Form             []string `bson:"form" json:"form"`
 	Position         int      `bson:"position" json:"position"`
+	GamesInHand      int      `bson:"-" json:"gamesInHand"` // Games fewer played than the club that has played the most
 }
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Lock is a lease on a job that only one server instance may run at a time
+type Lock struct {
+	ID        string    `bson:"_id" json:"id"`
+	Owner     string    `bson:"owner" json:"owner"` // Instance holding the lease
+	ExpiresAt time.Time `bson:"expiresAt" json:"expiresAt"`
+}
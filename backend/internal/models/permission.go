@@ -0,0 +1,64 @@
+package models
+
+// Permission is something a role allows its users to do
+type Permission string
+
+const (
+	PermManageMatches  Permission = "matches:manage" // Start, finish and reschedule matches; lineups, cards, shots, events
+	PermManagePlayers  Permission = "players:manage" // Players, coaches and team aliases
+	PermModerateReview Permission = "reviews:moderate"
+	PermManageSeasons  Permission = "seasons:manage" // Rollover, fixtures and the kickoff scheduler
+	PermManageUsers    Permission = "users:manage"   // Invites and role changes
+	PermViewAudit      Permission = "audit:read"     // The audit log of staff changes
+)
+
+// Staff roles; every other account is a RoleUser with no permissions
+const (
+	RoleMatchOfficial = "MATCH_OFFICIAL"
+	RoleDataEditor    = "DATA_EDITOR"
+	RoleModerator     = "MODERATOR"
+	RoleSuperAdmin    = "SUPERADMIN"
+)
+
+// rolePermissions lists what each role may do. ADMIN runs the league; only a
+// SUPERADMIN manages who else does.
+var rolePermissions = map[string][]Permission{
+	RoleUser:          nil,
+	RoleMatchOfficial: {PermManageMatches},
+	RoleDataEditor:    {PermManagePlayers},
+	RoleModerator:     {PermModerateReview},
+	RoleAdmin:         {PermManageMatches, PermManagePlayers, PermModerateReview, PermManageSeasons, PermViewAudit},
+	RoleSuperAdmin:    {PermManageMatches, PermManagePlayers, PermModerateReview, PermManageSeasons, PermViewAudit, PermManageUsers},
+}
+
+// ValidRole reports whether role is one of the roles above
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHas reports whether role grants perm
+func RoleHas(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// RolePermissions returns the permissions of role
+func RolePermissions(role string) []Permission {
+	return append([]Permission(nil), rolePermissions[role]...)
+}
+
+// RolesWith returns every role that grants perm
+func RolesWith(perm Permission) []string {
+	var roles []string
+	for role := range rolePermissions {
+		if RoleHas(role, perm) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
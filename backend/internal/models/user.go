@@ -6,18 +6,145 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	RoleUser  = "USER"
+	RoleAdmin = "ADMIN"
+)
+
 type User struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
 	DeletedAt *time.Time         `bson:"deletedAt,omitempty" json:"-"`
 
-	Email    string `bson:"email" json:"email"`
-	Password string `bson:"password" json:"-"`
-	FullName string `bson:"fullName" json:"fullName"`
+	Email           string     `bson:"email" json:"email"`
+	EmailVerifiedAt *time.Time `bson:"emailVerifiedAt,omitempty" json:"emailVerifiedAt,omitempty"`
+	Password        string     `bson:"password" json:"-"`
+	FullName        string     `bson:"fullName" json:"fullName"`
+	// Accounts at OIDC providers the user signs in with
+	Identities []ExternalIdentity `bson:"identities,omitempty" json:"identities,omitempty"`
+	MFA        *MFA               `bson:"mfa,omitempty" json:"mfa,omitempty"`
 	// Favorites
 	FavoriteTeams   []Team   `bson:"favoriteTeams" json:"favoriteTeams"`
 	FavoritePlayers []Player `bson:"favoritePlayers" json:"favoritePlayers"`
 
-	Role string `bson:"role" json:"role"` // GUEST, ADMIN
+	Role string `bson:"role" json:"role"` // USER, or a staff role (see permission.go)
+}
+
+// MFA is a user's two-factor authentication with a TOTP authenticator app. It is on
+// once the first code has confirmed enrolment.
+type MFA struct {
+	Secret        string     `bson:"secret" json:"-"` // Base32 TOTP secret
+	EnabledAt     *time.Time `bson:"enabledAt,omitempty" json:"enabledAt,omitempty"`
+	RecoveryCodes []string   `bson:"recoveryCodes,omitempty" json:"-"` // Hashes of the unused recovery codes
+	LastStep      int64      `bson:"lastStep,omitempty" json:"-"`      // Time step of the last code used, so each code works once
+}
+
+// MFAEnabled reports whether signing in needs a TOTP code too
+func (u *User) MFAEnabled() bool {
+	return u.MFA != nil && u.MFA.EnabledAt != nil
+}
+
+// ExternalIdentity links a user to an account at an OIDC provider. Subject is unique
+// per Issuer and never reused, unlike the email address.
+type ExternalIdentity struct {
+	Issuer   string    `bson:"issuer" json:"issuer"`
+	Subject  string    `bson:"subject" json:"subject"`
+	Email    string    `bson:"email" json:"email"` // As the provider gave it when linked
+	LinkedAt time.Time `bson:"linkedAt" json:"linkedAt"`
+}
+
+// OIDCState is a sign-in with an OIDC provider in progress, from the redirect to the
+// provider until its callback. Only a hash of the state parameter is stored.
+type OIDCState struct {
+	StateHash string    `bson:"_id" json:"-"`
+	Nonce     string    `bson:"nonce" json:"-"`
+	Verifier  string    `bson:"verifier" json:"-"` // PKCE code verifier
+	Redirect  string    `bson:"redirect,omitempty" json:"redirect,omitempty"`
+	ExpiresAt time.Time `bson:"expiresAt" json:"expiresAt"`
+}
+
+// Invite lets whoever holds its token take on a staff role, once, before it expires.
+// Only a hash of the token is stored.
+type Invite struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenHash string             `bson:"tokenHash" json:"-"`
+	Email     string             `bson:"email,omitempty" json:"email,omitempty"` // If set, only this address may accept
+	Role      string             `bson:"role" json:"role"`
+	CreatedBy string             `bson:"createdBy" json:"createdBy"` // User ID of the admin who issued it
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt time.Time          `bson:"expiresAt" json:"expiresAt"`
+	UsedAt    *time.Time         `bson:"usedAt,omitempty" json:"usedAt,omitempty"`
+	UsedBy    string             `bson:"usedBy,omitempty" json:"usedBy,omitempty"`
+}
+
+// How a user's role was changed
+const (
+	RoleChangeAdmin  = "admin"  // An admin promoted or demoted the user
+	RoleChangeInvite = "invite" // The user accepted an invite
+	RoleChangeCLI    = "cli"    // The epl CLI bootstrapped the admin
+)
+
+// RoleChange is an entry of the role audit trail
+type RoleChange struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     string             `bson:"userId" json:"userId"`
+	Email      string             `bson:"email" json:"email"`
+	From       string             `bson:"from,omitempty" json:"from,omitempty"` // Empty for a new account
+	To         string             `bson:"to" json:"to"`
+	Via        string             `bson:"via" json:"via"`
+	ActorID    string             `bson:"actorId,omitempty" json:"actorId,omitempty"`
+	ActorEmail string             `bson:"actorEmail,omitempty" json:"actorEmail,omitempty"`
+	Reason     string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// RefreshToken is one link of a session's chain of refresh tokens. Each token is used
+// once, in exchange for the next; all tokens of a session share a FamilyID. Only a hash
+// of the token is stored.
+type RefreshToken struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenHash  string             `bson:"tokenHash" json:"-"`
+	UserID     string             `bson:"userId" json:"userId"`
+	FamilyID   string             `bson:"familyId" json:"familyId"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+	UsedAt     *time.Time         `bson:"usedAt,omitempty" json:"usedAt,omitempty"`
+	RevokedAt  *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	ReplacedBy primitive.ObjectID `bson:"replacedBy,omitempty" json:"-"`
+	UserAgent  string             `bson:"userAgent,omitempty" json:"userAgent,omitempty"`
+	IP         string             `bson:"ip,omitempty" json:"ip,omitempty"`
+}
+
+// EmailVerified reports whether the user has confirmed their email address
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// Purposes of an EmailToken
+const (
+	EmailTokenVerify = "verify_email"
+	EmailTokenReset  = "reset_password"
+)
+
+// EmailToken is a single-use link sent to a user's address, to verify it or to reset
+// the password. Only a hash of the token is stored.
+type EmailToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenHash string             `bson:"tokenHash" json:"-"`
+	UserID    string             `bson:"userId" json:"userId"`
+	Email     string             `bson:"email" json:"email"` // The address it was sent to
+	Purpose   string             `bson:"purpose" json:"purpose"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt time.Time          `bson:"expiresAt" json:"expiresAt"`
+	UsedAt    *time.Time         `bson:"usedAt,omitempty" json:"usedAt,omitempty"`
+}
+
+// LoginAttempt tracks the failed logins of an email address, to lock it out for longer
+// after each failure past a threshold. It is deleted on a successful login.
+type LoginAttempt struct {
+	Email         string     `bson:"_id" json:"email"`
+	Failures      int        `bson:"failures" json:"failures"`
+	LastFailureAt time.Time  `bson:"lastFailureAt" json:"lastFailureAt"`
+	LockedUntil   *time.Time `bson:"lockedUntil,omitempty" json:"lockedUntil,omitempty"`
 }
@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// MockProvider is a minimal OIDC provider for local development and tests. It signs
+// anyone in as whatever email address they type (or pass as login_hint), always marked
+// verified, and checks the client ID, redirect URI and PKCE verifier like a real one.
+// It accepts any redirect URI.
+type MockProvider struct {
+	Issuer   string
+	ClientID string
+
+	mu    sync.Mutex
+	key   *rsa.PrivateKey
+	keyID string
+	keys  int // Keys made so far, to name the next one
+	codes map[string]mockCode
+}
+
+type mockCode struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	nonce       string
+	email       string
+	name        string
+	expiresAt   time.Time
+}
+
+// NewMockProvider creates a provider that will serve at issuer
+func NewMockProvider(issuer, clientID string) (*MockProvider, error) {
+	m := &MockProvider{
+		Issuer:   strings.TrimRight(issuer, "/"),
+		ClientID: clientID,
+		codes:    make(map[string]mockCode),
+	}
+	if err := m.RotateKey(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RotateKey replaces the signing key with a new one under a new key ID, as providers do
+// from time to time. Tokens signed with the old key no longer verify.
+func (m *MockProvider) RotateKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys++
+	m.key = key
+	m.keyID = fmt.Sprintf("mock-%d", m.keys)
+	return nil
+}
+
+// sign signs claims as an ID token with the current key
+func (m *MockProvider) sign(claims jwt.MapClaims) (string, error) {
+	m.mu.Lock()
+	key, keyID := m.key, m.keyID
+	m.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(key)
+}
+
+func (m *MockProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"issuer":                                m.Issuer,
+			"authorization_endpoint":                m.Issuer + "/authorize",
+			"token_endpoint":                        m.Issuer + "/token",
+			"jwks_uri":                              m.Issuer + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+			"code_challenge_methods_supported":      []string{"S256"},
+		})
+	case "/jwks":
+		m.mu.Lock()
+		pub, keyID := m.key.PublicKey, m.keyID
+		m.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}}})
+	case "/authorize":
+		m.authorize(w, r)
+	case "/token":
+		m.token(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+var mockLoginPage = template.Must(template.New("login").Parse(`<!doctype html>
+<title>Mock OIDC sign-in</title>
+<form method="get" action="/authorize">
+  {{range $k, $v := .Query}}{{if ne $k "login_hint"}}<input type="hidden" name="{{$k}}" value="{{index $v 0}}">{{end}}{{end}}
+  <p>Sign in to {{.ClientID}} as</p>
+  <p><input name="login_hint" type="email" placeholder="email" required autofocus></p>
+  <p><input name="name" placeholder="full name (optional)"></p>
+  <button>Sign in</button>
+</form>`))
+
+func (m *MockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("client_id") != m.ClientID || redirectURI == "" {
+		http.Error(w, "unknown client_id or missing redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "expected response_type=code with an S256 code_challenge", http.StatusBadRequest)
+		return
+	}
+
+	email := q.Get("login_hint")
+	if email == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = mockLoginPage.Execute(w, map[string]interface{}{"Query": q, "ClientID": m.ClientID})
+		return
+	}
+
+	code, err := RandomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	m.mu.Lock()
+	m.codes[code] = mockCode{
+		clientID:    m.ClientID,
+		redirectURI: redirectURI,
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		email:       email,
+		name:        q.Get("name"),
+		expiresAt:   time.Now().Add(time.Minute),
+	}
+	m.mu.Unlock()
+
+	back, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	bq := back.Query()
+	bq.Set("code", code)
+	bq.Set("state", q.Get("state"))
+	back.RawQuery = bq.Encode()
+	http.Redirect(w, r, back.String(), http.StatusFound)
+}
+
+func (m *MockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+	code := r.PostForm.Get("code")
+	m.mu.Lock()
+	c, ok := m.codes[code]
+	delete(m.codes, code) // Codes work once
+	m.mu.Unlock()
+
+	switch {
+	case !ok || time.Now().After(c.expiresAt):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "unknown or expired code"})
+		return
+	case r.PostForm.Get("client_id") != c.clientID || r.PostForm.Get("redirect_uri") != c.redirectURI:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "client_id or redirect_uri mismatch"})
+		return
+	case Challenge(r.PostForm.Get("code_verifier")) != c.challenge:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            m.Issuer,
+		"sub":            "mock|" + strings.ToLower(c.email),
+		"aud":            c.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          c.nonce,
+		"email":          c.email,
+		"email_verified": true,
+	}
+	if c.name != "" {
+		claims["name"] = c.name
+	}
+	idToken, err := m.sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	accessToken, _ := RandomString()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,269 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID    = "epl"
+	testRedirectURL = "http://app.test/api/auth/oidc/callback"
+)
+
+// mockServer serves a MockProvider and counts the requests for each path
+type mockServer struct {
+	*httptest.Server
+	mock *MockProvider
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+	s := &mockServer{requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		s.mu.Unlock()
+		s.mock.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	mock, err := NewMockProvider(s.URL, testClientID)
+	if err != nil {
+		t.Fatalf("NewMockProvider: %v", err)
+	}
+	s.mock = mock
+	return s
+}
+
+func (s *mockServer) count(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func (s *mockServer) provider(t *testing.T, issuer, clientID string) *Provider {
+	t.Helper()
+	p, err := NewProvider(Config{Issuer: issuer, ClientID: clientID, RedirectURL: testRedirectURL, HTTPClient: s.Client()})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	return p
+}
+
+// claims are the ID token claims the mock issues, which tests then spoil
+func (s *mockServer) claims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            "mock|fan@example.com",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          nonce,
+		"email":          "fan@example.com",
+		"email_verified": true,
+	}
+}
+
+// authorize goes through the provider's sign-in page as email, like a browser would, and
+// returns the code and state it redirects back with
+func (s *mockServer) authorize(t *testing.T, p *Provider, email, state, nonce, verifier string) (code, backState string) {
+	t.Helper()
+	target, err := p.AuthURL(context.Background(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthURL: %v", err)
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge") != Challenge(verifier) || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("AuthURL has challenge %q (%s), want the S256 challenge of the verifier", q.Get("code_challenge"), q.Get("code_challenge_method"))
+	}
+	q.Set("login_hint", email)
+	u.RawQuery = q.Encode()
+
+	client := *s.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(u.String())
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize answered %d, want a redirect", resp.StatusCode)
+	}
+	back, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return back.Query().Get("code"), back.Query().Get("state")
+}
+
+func randomValues(t *testing.T) (state, nonce, verifier string) {
+	t.Helper()
+	var values [3]string
+	for i := range values {
+		v, err := RandomString()
+		if err != nil {
+			t.Fatalf("RandomString: %v", err)
+		}
+		values[i] = v
+	}
+	return values[0], values[1], values[2]
+}
+
+func TestSignIn(t *testing.T) {
+	srv := newMockServer(t)
+	p := srv.provider(t, srv.URL, testClientID)
+	state, nonce, verifier := randomValues(t)
+
+	code, backState := srv.authorize(t, p, "Fan@Example.com", state, nonce, verifier)
+	if backState != state {
+		t.Errorf("got state %q back, want %q", backState, state)
+	}
+	claims, err := p.Exchange(context.Background(), code, verifier, nonce)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	want := Claims{Issuer: srv.URL, Subject: "mock|fan@example.com", Email: "Fan@Example.com", EmailVerified: true}
+	if *claims != want {
+		t.Errorf("got %+v, want %+v", *claims, want)
+	}
+
+	// Codes work once
+	if _, err := p.Exchange(context.Background(), code, verifier, nonce); err == nil {
+		t.Error("a used code was accepted again")
+	}
+}
+
+func TestExchangeChecksVerifierAndNonce(t *testing.T) {
+	srv := newMockServer(t)
+	p := srv.provider(t, srv.URL, testClientID)
+
+	state, nonce, verifier := randomValues(t)
+	code, _ := srv.authorize(t, p, "fan@example.com", state, nonce, verifier)
+	_, _, otherVerifier := randomValues(t)
+	if _, err := p.Exchange(context.Background(), code, otherVerifier, nonce); err == nil {
+		t.Error("the code was exchanged without its PKCE verifier")
+	}
+
+	state, nonce, verifier = randomValues(t)
+	code, _ = srv.authorize(t, p, "fan@example.com", state, nonce, verifier)
+	if _, err := p.Exchange(context.Background(), code, verifier, "another nonce"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("got %v for a token with another nonce, want ErrInvalidIDToken", err)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	srv := newMockServer(t)
+	p := srv.provider(t, srv.URL, testClientID)
+
+	tests := []struct {
+		name  string
+		spoil func(jwt.MapClaims)
+	}{
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "another-client" }},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() }},
+		{"no expiry", func(c jwt.MapClaims) { delete(c, "exp") }},
+		{"wrong nonce", func(c jwt.MapClaims) { c["nonce"] = "replayed" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := srv.claims("nonce")
+			tt.spoil(claims)
+			raw, err := srv.mock.sign(claims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := p.Verify(context.Background(), raw, "nonce"); !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("got %v, want ErrInvalidIDToken", err)
+			}
+		})
+	}
+
+	t.Run("within the leeway", func(t *testing.T) {
+		claims := srv.claims("nonce")
+		claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+		raw, err := srv.mock.sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Verify(context.Background(), raw, "nonce"); err != nil {
+			t.Errorf("a token 30s past expiry was rejected: %v", err)
+		}
+	})
+
+	t.Run("audience of another provider", func(t *testing.T) {
+		other := srv.provider(t, srv.URL, "another-client")
+		raw, err := srv.mock.sign(srv.claims("nonce"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := other.Verify(context.Background(), raw, "nonce"); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("got %v, want ErrInvalidIDToken", err)
+		}
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	srv := newMockServer(t)
+	p := srv.provider(t, srv.URL, testClientID)
+
+	old, err := srv.mock.sign(srv.claims("nonce"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := p.Verify(context.Background(), old, "nonce"); err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+	}
+	if n := srv.count("/jwks"); n != 1 {
+		t.Fatalf("fetched the keys %d times, want once and then cached", n)
+	}
+
+	// A token with an unknown kid refetches the keys
+	if err := srv.mock.RotateKey(); err != nil {
+		t.Fatalf("RotateKey: %v", err)
+	}
+	rotated, err := srv.mock.sign(srv.claims("nonce"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Verify(context.Background(), rotated, "nonce"); err != nil {
+		t.Fatalf("Verify after rotation: %v", err)
+	}
+	if n := srv.count("/jwks"); n != 2 {
+		t.Errorf("fetched the keys %d times, want 2", n)
+	}
+
+	// The old key has been retired
+	if _, err := p.Verify(context.Background(), old, "nonce"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("got %v for a token signed with the retired key, want ErrInvalidIDToken", err)
+	}
+}
+
+func TestDiscoveryChecksIssuer(t *testing.T) {
+	srv := newMockServer(t)
+	srv.mock.Issuer = "https://accounts.example.com"
+	p := srv.provider(t, srv.URL, testClientID)
+
+	state, nonce, verifier := randomValues(t)
+	if _, err := p.AuthURL(context.Background(), state, nonce, verifier); err == nil {
+		t.Error("a discovery document for another issuer was accepted")
+	}
+}
@@ -0,0 +1,297 @@
+// Package openfootball parses fixture files in the openfootball football.txt format,
+// e.g. england-master/2025-26/1-premierleague.txt:
+//
+//	= English Premier League 2025/26
+//
+//	» Matchday 1
+//	  Fri Aug/15 2025
+//	    20.00  Liverpool FC            v AFC Bournemouth          4-2 (1-0)
+//	  Sat Aug/16
+//	    12.30  Aston Villa FC          v Newcastle United FC      0-0
+//	           Sunderland AFC          v West Ham United FC       [postponed]
+//
+// Dates without a year take the year of the previous date, rolling over to the next
+// year when the month goes backwards. A match line without a kick-off time reuses the
+// time of the line above it. Kick-off times are UK time, and are converted to UTC.
+package openfootball
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Location must load without the system's zone database
+)
+
+type Status string
+
+const (
+	StatusPlayed    Status = "PLAYED"
+	StatusScheduled Status = "SCHEDULED"
+	StatusPostponed Status = "POSTPONED"
+	StatusAbandoned Status = "ABANDONED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+type Score struct {
+	Home int `json:"home"`
+	Away int `json:"away"`
+}
+
+func (s Score) String() string {
+	return fmt.Sprintf("%d-%d", s.Home, s.Away)
+}
+
+type Match struct {
+	Matchday int
+	// Date is the kick-off in UTC, or midnight UK time when HasTime is false
+	Date     time.Time
+	HasTime  bool // False when the file gives no kick-off time for the day
+	HomeTeam string
+	AwayTeam string
+	Score    *Score // Full-time score, nil until played
+	HalfTime *Score
+	Status   Status
+	Line     int // Line number in the source file
+}
+
+type Season struct {
+	Title   string // e.g. "English Premier League 2025/26"
+	Season  string // e.g. "2025/26", empty when the title has none
+	Matches []Match
+}
+
+// ParseError reports a line that could not be understood
+type ParseError struct {
+	Line int
+	Text string
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Msg, e.Text)
+}
+
+var (
+	titleRe    = regexp.MustCompile(`^=+\s*(.+?)\s*$`)
+	seasonRe   = regexp.MustCompile(`\b(\d{4})(?:/(\d{2,4}))?\b`)
+	matchdayRe = regexp.MustCompile(`^(?:»\s*)?(?i:matchday|round|week)\s+(\d+)\b`)
+	// [Fri] Aug/15 [2025], optionally in square brackets
+	dateRe = regexp.MustCompile(`^\[?(?:(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun)\s+)?([A-Z][a-z]{2})/(\d{1,2})(?:\s+(\d{4}))?\]?$`)
+	timeRe = regexp.MustCompile(`^(\d{1,2})[.:](\d{2})\s+`)
+	// Home v Away [4-2 [(1-0)]]
+	versusRe = regexp.MustCompile(`^(.+?)\s+vs?\.?\s+(.+?)(?:\s+(\d+)-(\d+)(?:\s+\((\d+)-(\d+)\))?)?$`)
+	// Home 4-2 [(1-0)] Away
+	inlineScoreRe = regexp.MustCompile(`^(.+?)\s+(\d+)-(\d+)(?:\s+\((\d+)-(\d+)\))?\s+(.+)$`)
+	markerRe      = regexp.MustCompile(`(?i)\s*(\[[^\]]*\]|\bP-P\b|\bpostp\b\.?|\babd\b\.?|\bcanc\b\.?)`)
+)
+
+// Location is the time zone of the dates and times in the files
+var Location = mustLoadLocation("Europe/London")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+var months = map[string]time.Month{
+	"Jan": time.January, "Feb": time.February, "Mar": time.March, "Apr": time.April,
+	"May": time.May, "Jun": time.June, "Jul": time.July, "Aug": time.August,
+	"Sep": time.September, "Oct": time.October, "Nov": time.November, "Dec": time.December,
+}
+
+// ParseFile parses an openfootball .txt file
+func ParseFile(path string) (*Season, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a whole openfootball fixture file
+func Parse(r io.Reader) (*Season, error) {
+	p := &parser{season: &Season{}}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.line++
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p.season, nil
+}
+
+type parser struct {
+	season   *Season
+	line     int
+	matchday int
+	year     int // Year of the current date header, 0 until known
+	month    time.Month
+	day      time.Time // Current date header, zero until the first one
+	hour     int
+	minute   int
+	hasTime  bool
+}
+
+func (p *parser) errorf(text, format string, args ...interface{}) error {
+	return &ParseError{Line: p.line, Text: text, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseLine(raw string) error {
+	text := stripComment(raw)
+	if text == "" {
+		return nil
+	}
+
+	if m := titleRe.FindStringSubmatch(text); m != nil {
+		p.season.Title = m[1]
+		if s := seasonRe.FindStringSubmatch(m[1]); s != nil {
+			p.season.Season = s[0]
+			if p.year == 0 {
+				p.year, _ = strconv.Atoi(s[1])
+			}
+		}
+		return nil
+	}
+
+	if m := matchdayRe.FindStringSubmatch(text); m != nil {
+		p.matchday, _ = strconv.Atoi(m[1])
+		return nil
+	}
+
+	if m := dateRe.FindStringSubmatch(text); m != nil {
+		return p.parseDate(text, m)
+	}
+
+	return p.parseMatch(text)
+}
+
+// stripComment removes a "#" comment and surrounding whitespace
+func stripComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line)
+}
+
+func (p *parser) parseDate(text string, m []string) error {
+	month, ok := months[m[1]]
+	if !ok {
+		return p.errorf(text, "unknown month %s", m[1])
+	}
+	day, _ := strconv.Atoi(m[2])
+
+	switch {
+	case m[3] != "":
+		p.year, _ = strconv.Atoi(m[3])
+	case p.year == 0:
+		return p.errorf(text, "date without a year and no earlier year to carry over")
+	case month < p.month:
+		// Aug ... Dec then Jan: the season has moved into the next year
+		p.year++
+	}
+	p.month = month
+
+	date := time.Date(p.year, month, day, 0, 0, 0, 0, Location)
+	if date.Day() != day {
+		return p.errorf(text, "invalid date")
+	}
+	p.day = date
+	p.hasTime = false
+	return nil
+}
+
+func (p *parser) parseMatch(text string) error {
+	if p.day.IsZero() {
+		return p.errorf(text, "match before any date header")
+	}
+
+	if m := timeRe.FindStringSubmatch(text); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour > 23 || minute > 59 {
+			return p.errorf(text, "invalid kick-off time")
+		}
+		p.hour, p.minute, p.hasTime = hour, minute, true
+		text = text[len(m[0]):]
+	}
+
+	status := StatusScheduled
+	for _, marker := range markerRe.FindAllString(text, -1) {
+		if s := markerStatus(marker); s != "" {
+			status = s
+		}
+	}
+	text = strings.TrimSpace(markerRe.ReplaceAllString(text, ""))
+
+	match := Match{
+		Matchday: p.matchday,
+		Date:     p.day.UTC(),
+		HasTime:  p.hasTime,
+		Status:   status,
+		Line:     p.line,
+	}
+	if p.hasTime {
+		// Built from the wall clock rather than added on, so kick-offs on the days the
+		// clocks change come out right
+		y, mo, d := p.day.Date()
+		match.Date = time.Date(y, mo, d, p.hour, p.minute, 0, 0, Location).UTC()
+	}
+
+	var score []string
+	if m := versusRe.FindStringSubmatch(text); m != nil {
+		match.HomeTeam, match.AwayTeam = m[1], m[2]
+		score = m[3:7]
+	} else if m := inlineScoreRe.FindStringSubmatch(text); m != nil {
+		match.HomeTeam, match.AwayTeam = m[1], m[6]
+		score = m[2:6]
+	} else {
+		return p.errorf(text, "unrecognised line")
+	}
+
+	if score[0] != "" {
+		match.Score = parseScore(score[0], score[1])
+		if score[2] != "" {
+			match.HalfTime = parseScore(score[2], score[3])
+		}
+		// A scoreline on an abandoned match is the score when play stopped
+		if match.Status == StatusScheduled {
+			match.Status = StatusPlayed
+		}
+	}
+
+	p.season.Matches = append(p.season.Matches, match)
+	return nil
+}
+
+func parseScore(home, away string) *Score {
+	h, _ := strconv.Atoi(home)
+	a, _ := strconv.Atoi(away)
+	return &Score{Home: h, Away: a}
+}
+
+// markerStatus maps a status marker such as "[postponed]" or "P-P" to a status
+func markerStatus(marker string) Status {
+	m := strings.ToLower(strings.Trim(strings.TrimSpace(marker), "[]"))
+	switch {
+	case strings.HasPrefix(m, "postp"), m == "p-p":
+		return StatusPostponed
+	case strings.HasPrefix(m, "abd"), strings.HasPrefix(m, "abandon"):
+		return StatusAbandoned
+	case strings.HasPrefix(m, "canc"):
+		return StatusCancelled
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package openfootball
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Match
+	}{
+		{
+			name: "year carried over and rolled into the next year",
+			input: `= English Premier League 2025/26
+» Matchday 19
+  Sat Dec/27
+    15.00  Arsenal FC  v  Brighton & Hove Albion FC
+» Matchday 20
+  Thu Jan/1
+    20.00  Chelsea FC  v  Fulham FC`,
+			want: []Match{
+				{Matchday: 19, Date: utc(2025, time.December, 27, 15, 0), HasTime: true, HomeTeam: "Arsenal FC", AwayTeam: "Brighton & Hove Albion FC", Status: StatusScheduled},
+				{Matchday: 20, Date: utc(2026, time.January, 1, 20, 0), HasTime: true, HomeTeam: "Chelsea FC", AwayTeam: "Fulham FC", Status: StatusScheduled},
+			},
+		},
+		{
+			name: "kick-off time carried to the next line",
+			input: `Sat Aug/16 2025
+  12.30  Aston Villa FC  v  Newcastle United FC
+         Brighton & Hove Albion FC  v  Fulham FC
+Sun Aug/17
+         Chelsea FC  v  Crystal Palace FC`,
+			want: []Match{
+				{Date: utc(2025, time.August, 16, 11, 30), HasTime: true, HomeTeam: "Aston Villa FC", AwayTeam: "Newcastle United FC", Status: StatusScheduled},
+				{Date: utc(2025, time.August, 16, 11, 30), HasTime: true, HomeTeam: "Brighton & Hove Albion FC", AwayTeam: "Fulham FC", Status: StatusScheduled},
+				{Date: utc(2025, time.August, 16, 23, 0), HomeTeam: "Chelsea FC", AwayTeam: "Crystal Palace FC", Status: StatusScheduled},
+			},
+		},
+		{
+			name: "UK time to UTC either side of the clocks changing",
+			input: `Sat Oct/25 2025
+  15.00  Everton FC  v  Tottenham Hotspur FC
+Sun Oct/26
+  14.00  Leeds United FC  v  West Ham United FC`,
+			want: []Match{
+				{Date: utc(2025, time.October, 25, 14, 0), HasTime: true, HomeTeam: "Everton FC", AwayTeam: "Tottenham Hotspur FC", Status: StatusScheduled},
+				{Date: utc(2025, time.October, 26, 14, 0), HasTime: true, HomeTeam: "Leeds United FC", AwayTeam: "West Ham United FC", Status: StatusScheduled},
+			},
+		},
+		{
+			name: "full-time and half-time scores",
+			input: `Fri Aug/15 2025
+  20.00  Liverpool FC  v  AFC Bournemouth  4-2 (1-0)
+  20.00  Burnley FC  2-0  Sunderland AFC
+  20.00  Brentford FC  1-1 (0-1)  Wolverhampton Wanderers FC`,
+			want: []Match{
+				{Date: utc(2025, time.August, 15, 19, 0), HasTime: true, HomeTeam: "Liverpool FC", AwayTeam: "AFC Bournemouth", Score: &Score{4, 2}, HalfTime: &Score{1, 0}, Status: StatusPlayed},
+				{Date: utc(2025, time.August, 15, 19, 0), HasTime: true, HomeTeam: "Burnley FC", AwayTeam: "Sunderland AFC", Score: &Score{2, 0}, Status: StatusPlayed},
+				{Date: utc(2025, time.August, 15, 19, 0), HasTime: true, HomeTeam: "Brentford FC", AwayTeam: "Wolverhampton Wanderers FC", Score: &Score{1, 1}, HalfTime: &Score{0, 1}, Status: StatusPlayed},
+			},
+		},
+		{
+			name: "status markers",
+			input: `[Sat Jan/10 2026]
+  Sunderland AFC  v  West Ham United FC  [postponed]
+  Everton FC  v  Fulham FC  P-P
+  Leeds United FC  v  Burnley FC  1-0  [abandoned]
+  Chelsea FC  v  Arsenal FC  [cancelled]`,
+			want: []Match{
+				{Date: utc(2026, time.January, 10, 0, 0), HomeTeam: "Sunderland AFC", AwayTeam: "West Ham United FC", Status: StatusPostponed},
+				{Date: utc(2026, time.January, 10, 0, 0), HomeTeam: "Everton FC", AwayTeam: "Fulham FC", Status: StatusPostponed},
+				{Date: utc(2026, time.January, 10, 0, 0), HomeTeam: "Leeds United FC", AwayTeam: "Burnley FC", Score: &Score{1, 0}, Status: StatusAbandoned},
+				{Date: utc(2026, time.January, 10, 0, 0), HomeTeam: "Chelsea FC", AwayTeam: "Arsenal FC", Status: StatusCancelled},
+			},
+		},
+		{
+			name: "comments and blank lines",
+			input: `# England, top flight
+
+Sat Aug/16 2025   # opening weekend
+  15.00  Arsenal FC  v  Leeds United FC  # live on TV
+#  15.00  Chelsea FC  v  Fulham FC`,
+			want: []Match{
+				{Date: utc(2025, time.August, 16, 14, 0), HasTime: true, HomeTeam: "Arsenal FC", AwayTeam: "Leeds United FC", Status: StatusScheduled},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			got := season.Matches
+			for i := range got {
+				got[i].Line = 0
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matches differ\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTitle(t *testing.T) {
+	season, err := Parse(strings.NewReader("= English Premier League 2025/26\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if season.Title != "English Premier League 2025/26" || season.Season != "2025/26" {
+		t.Errorf("got title %q, season %q", season.Title, season.Season)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		msg   string
+	}{
+		{"match before a date", "= EPL 2025/26\n  Arsenal FC v Chelsea FC", 2, "match before any date header"},
+		{"date without a year", "Sat Aug/16\n", 1, "date without a year"},
+		{"unknown month", "Sat Aug/16 2025\nFri Foo/15", 2, "unknown month Foo"},
+		{"invalid date", "Sat Feb/30 2026", 1, "invalid date"},
+		{"invalid kick-off time", "Sat Aug/16 2025\n  25.00  Arsenal FC v Chelsea FC", 2, "invalid kick-off time"},
+		{"unrecognised line", "Sat Aug/16 2025\n\n  Arsenal FC against Chelsea FC", 3, "unrecognised line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("got %v, want a ParseError", err)
+			}
+			if perr.Line != tt.line || !strings.Contains(perr.Msg, tt.msg) {
+				t.Errorf("got line %d %q, want line %d %q", perr.Line, perr.Msg, tt.line, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package ratelimit counts requests in fixed windows. The memory store suits a single
+// server; the Mongo store shares the counts between every instance.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Store counts hits of a key in the current window
+type Store interface {
+	// Hit adds one to key's count in the window of the given length that contains now,
+	// and returns the new count and when the window ends
+	Hit(key string, window time.Duration) (count int, resetAt time.Time, err error)
+}
+
+// windowStart is the start of the fixed window containing t
+func windowStart(t time.Time, window time.Duration) time.Time {
+	return t.Truncate(window)
+}
+
+// MemoryStore keeps counts in the process
+type MemoryStore struct {
+	mu        sync.Mutex
+	counts    map[string]*memoryCount
+	lastSweep time.Time
+}
+
+type memoryCount struct {
+	count   int
+	resetAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counts: make(map[string]*memoryCount)}
+}
+
+func (s *MemoryStore) Hit(key string, window time.Duration) (int, time.Time, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop finished windows now and then, so the map doesn't grow forever
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, c := range s.counts {
+			if !now.Before(c.resetAt) {
+				delete(s.counts, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	c, ok := s.counts[key]
+	if !ok || !now.Before(c.resetAt) {
+		c = &memoryCount{resetAt: windowStart(now, window).Add(window)}
+		s.counts[key] = c
+	}
+	c.count++
+	return c.count, c.resetAt, nil
+}
+
+// MongoStore keeps counts in a collection, one document per key and window. Give the
+// collection a TTL index on expiresAt so finished windows are deleted.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoStore(collection *mongo.Collection) *MongoStore {
+	return &MongoStore{collection: collection}
+}
+
+func (s *MongoStore) Hit(key string, window time.Duration) (int, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := windowStart(time.Now(), window)
+	resetAt := start.Add(window)
+	var doc struct {
+		Count int `bson:"count"`
+	}
+	err := s.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": fmt.Sprintf("%s|%d", key, start.Unix())},
+		bson.M{"$inc": bson.M{"count": 1}, "$setOnInsert": bson.M{"expiresAt": resetAt}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&doc)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return doc.Count, resetAt, nil
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindowStart(t *testing.T) {
+	base := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at     time.Time
+		window time.Duration
+		want   time.Time
+	}{
+		{base, time.Minute, base},
+		{base.Add(59 * time.Second), time.Minute, base},
+		{base.Add(time.Minute), time.Minute, base.Add(time.Minute)},
+		{base.Add(14*time.Minute + 59*time.Second), 15 * time.Minute, base},
+		{base.Add(31 * time.Minute), 15 * time.Minute, base.Add(30 * time.Minute)},
+		{base.Add(-time.Second), time.Hour, base.Add(-time.Hour)},
+	}
+	for _, tt := range tests {
+		if got := windowStart(tt.at, tt.window); !got.Equal(tt.want) {
+			t.Errorf("windowStart(%s, %s) = %s, want %s", tt.at.Format(time.TimeOnly), tt.window, got.Format(time.TimeOnly), tt.want.Format(time.TimeOnly))
+		}
+	}
+}
+
+func TestMemoryStoreCountsPerWindow(t *testing.T) {
+	s := NewMemoryStore()
+	before := time.Now()
+	for want := 1; want <= 3; want++ {
+		count, resetAt, err := s.Hit("login:ip:10.0.0.1", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != want {
+			t.Errorf("hit %d: got count %d", want, count)
+		}
+		// The window ends on the next whole hour, not an hour after the first hit
+		if !resetAt.Equal(resetAt.Truncate(time.Hour)) || !resetAt.After(before) || resetAt.After(before.Add(time.Hour)) {
+			t.Errorf("hit %d: got reset at %s, want the first whole hour after %s", want, resetAt, before)
+		}
+	}
+
+	// Keys are counted apart
+	if count, _, _ := s.Hit("login:ip:10.0.0.2", time.Hour); count != 1 {
+		t.Errorf("got count %d for another key, want 1", count)
+	}
+
+	// A finished window starts again from one
+	s.counts["login:ip:10.0.0.1"].resetAt = time.Now().Add(-time.Second)
+	if count, _, _ := s.Hit("login:ip:10.0.0.1", time.Hour); count != 1 {
+		t.Errorf("got count %d after the window ended, want 1", count)
+	}
+}
+
+func TestMemoryStoreSweepsFinishedWindows(t *testing.T) {
+	s := NewMemoryStore()
+	s.Hit("old", time.Hour)
+	s.counts["old"].resetAt = time.Now().Add(-time.Second)
+	s.lastSweep = time.Now().Add(-2 * time.Minute)
+
+	s.Hit("new", time.Hour)
+	if _, ok := s.counts["old"]; ok {
+		t.Error("a finished window was kept")
+	}
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type APIKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{
+		collection: database.DB.Collection("api_keys"),
+	}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(key *models.APIKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if key.ID.IsZero() {
+		key.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, key)
+	return err
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(keyHash string) (*models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var key models.APIKey
+	if err := r.collection.FindOne(ctx, bson.M{"keyHash": keyHash}).Decode(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetUserAPIKeys returns a user's keys, revoked and expired ones included, newest first
+func (r *APIKeyRepository) GetUserAPIKeys(userID string) ([]models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	keys := []models.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// TouchAPIKey records that a key was used. It writes at most once a minute per key, so
+// a busy script doesn't turn every request into a database write.
+func (r *APIKeyRepository) TouchAPIKey(id primitive.ObjectID, ip string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"lastUsedAt": bson.M{"$exists": false}},
+			bson.M{"lastUsedAt": bson.M{"$lt": now.Add(-time.Minute)}},
+		},
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"lastUsedAt": now, "lastUsedIp": ip}})
+	return err
+}
+
+// RevokeAPIKey revokes one of a user's keys; mongo.ErrNoDocuments means the user has no
+// such key, or it was already revoked
+func (r *APIKeyRepository) RevokeAPIKey(id primitive.ObjectID, userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "userId": userID, "revokedAt": bson.M{"$exists": false}}
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RevokeUserAPIKeys revokes every key of a user
+func (r *APIKeyRepository) RevokeUserAPIKeys(userID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"userId": userID, "revokedAt": bson.M{"$exists": false}}
+	res, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
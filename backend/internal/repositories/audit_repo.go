@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		collection: database.DB.Collection("audit_log"),
+	}
+}
+
+// AuditFilter narrows GetAuditEntries; zero fields match everything
+type AuditFilter struct {
+	Actor      string // Actor ID or email
+	Action     string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+}
+
+func (r *AuditRepository) CreateAuditEntry(entry *models.AuditEntry) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// GetAuditEntries returns the latest entries matching filter, newest first
+func (r *AuditRepository) GetAuditEntries(filter AuditFilter, limit int64) ([]models.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.Actor != "" {
+		query["$or"] = bson.A{bson.M{"actorId": filter.Actor}, bson.M{"actorEmail": filter.Actor}}
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.TargetType != "" {
+		query["targetType"] = filter.TargetType
+	}
+	if filter.TargetID != "" {
+		query["targetId"] = filter.TargetID
+	}
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		query["createdAt"] = createdAt
+	}
+
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	entries := []models.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Snapshot reads any document by _id, for the before and after of an audit entry. IDs
+// are tried as strings first, then as ObjectIDs. A missing document is nil, not an error.
+func (r *AuditRepository) Snapshot(collection, id string) (bson.M, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ids := bson.A{id}
+	if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+		ids = append(ids, objID)
+	}
+	var doc bson.M
+	err := database.DB.Collection(collection).FindOne(ctx, bson.M{"_id": bson.M{"$in": ids}}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	return doc, err
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DisciplineRepository struct {
+	events      *mongo.Collection
+	suspensions *mongo.Collection
+}
+
+func NewDisciplineRepository() *DisciplineRepository {
+	return &DisciplineRepository{
+		events:      database.DB.Collection("match_events"),
+		suspensions: database.DB.Collection("suspensions"),
+	}
+}
+
+// CreateMatchEvent stores a card, shot or substitution event
+func (r *DisciplineRepository) CreateMatchEvent(event *models.MatchEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.events.InsertOne(ctx, event)
+	return err
+}
+
+// GetMatchEvents returns the events recorded for a match in minute order
+func (r *DisciplineRepository) GetMatchEvents(matchID string) ([]models.MatchEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "minute", Value: 1}})
+	cursor, err := r.events.Find(ctx, bson.M{"matchId": matchID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.MatchEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// DeleteMatchEvents removes the cards, shots and substitutions recorded for a match
+func (r *DisciplineRepository) DeleteMatchEvents(matchID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.events.DeleteMany(ctx, bson.M{"matchId": matchID})
+	return err
+}
+
+// GetCardEvents returns every yellow and red card of a season
+func (r *DisciplineRepository) GetCardEvents(seasonID string) ([]models.MatchEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := seasonFilter(seasonID, bson.M{"type": bson.M{"$in": []models.EventType{models.YellowCard, models.RedCard}}})
+	opts := options.Find().SetSort(bson.D{
+		{Key: "matchday", Value: 1},
+		{Key: "minute", Value: 1},
+	})
+	cursor, err := r.events.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.MatchEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// CreateSuspension inserts a suspension unless one with the same ID already exists
+func (r *DisciplineRepository) CreateSuspension(suspension *models.Suspension) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Update().SetUpsert(true)
+	_, err := r.suspensions.UpdateOne(ctx,
+		bson.M{"_id": suspension.ID},
+		bson.M{"$setOnInsert": suspension},
+		opts,
+	)
+	return err
+}
+
+// GetSuspensions returns a season's suspensions, optionally only the ones still being served
+func (r *DisciplineRepository) GetSuspensions(seasonID string, activeOnly bool) ([]models.Suspension, error) {
+	return r.findSuspensions(seasonFilter(seasonID, bson.M{}), activeOnly)
+}
+
+// GetTeamSuspensions returns a team's suspensions in a season, optionally only the ones still being served
+func (r *DisciplineRepository) GetTeamSuspensions(seasonID, teamID string, activeOnly bool) ([]models.Suspension, error) {
+	return r.findSuspensions(seasonFilter(seasonID, bson.M{"teamId": teamID}), activeOnly)
+}
+
+func (r *DisciplineRepository) findSuspensions(filter bson.M, activeOnly bool) ([]models.Suspension, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if activeOnly {
+		filter["$expr"] = bson.M{"$lt": bson.A{bson.M{"$size": "$servedMatchIds"}, "$matches"}}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.suspensions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var suspensions []models.Suspension
+	if err := cursor.All(ctx, &suspensions); err != nil {
+		return nil, err
+	}
+	return suspensions, nil
+}
+
+// DeleteMatchSuspensions removes the suspensions the cards of a match triggered
+func (r *DisciplineRepository) DeleteMatchSuspensions(matchID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.suspensions.DeleteMany(ctx, bson.M{"triggerMatchId": matchID})
+	return err
+}
+
+// MarkSuspensionServed records that a suspended player sat out a match
+func (r *DisciplineRepository) MarkSuspensionServed(suspensionID, matchID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.suspensions.UpdateOne(ctx,
+		bson.M{"_id": suspensionID},
+		bson.M{"$addToSet": bson.M{"servedMatchIds": matchID}},
+	)
+	return err
+}
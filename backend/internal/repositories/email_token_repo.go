@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EmailTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewEmailTokenRepository() *EmailTokenRepository {
+	return &EmailTokenRepository{
+		collection: database.DB.Collection("email_tokens"),
+	}
+}
+
+func (r *EmailTokenRepository) CreateEmailToken(token *models.EmailToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+// UseEmailToken marks the unused, unexpired token with tokenHash and purpose as used.
+// It is atomic, so a link works once; mongo.ErrNoDocuments means there was no such token.
+func (r *EmailTokenRepository) UseEmailToken(tokenHash, purpose string) (*models.EmailToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"tokenHash": tokenHash,
+		"purpose":   purpose,
+		"usedAt":    bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": now},
+	}
+	var token models.EmailToken
+	err := r.collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": bson.M{"usedAt": now}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&token)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteUserTokens removes a user's unused tokens for purpose, so only the latest link works
+func (r *EmailTokenRepository) DeleteUserTokens(userID, purpose string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"userId":  userID,
+		"purpose": purpose,
+		"usedAt":  bson.M{"$exists": false},
+	})
+	return err
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type InviteRepository struct {
+	collection *mongo.Collection
+}
+
+func NewInviteRepository() *InviteRepository {
+	return &InviteRepository{
+		collection: database.DB.Collection("invites"),
+	}
+}
+
+func (r *InviteRepository) CreateInvite(invite *models.Invite) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if invite.ID.IsZero() {
+		invite.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, invite)
+	return err
+}
+
+// GetPendingInvites returns the invites that are neither used nor expired, newest first
+func (r *InviteRepository) GetPendingInvites() ([]models.Invite, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"usedAt": bson.M{"$exists": false}, "expiresAt": bson.M{"$gt": time.Now()}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"createdAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	invites := []models.Invite{}
+	if err := cursor.All(ctx, &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// usableInvite matches the unused, unexpired invite with tokenHash that email may redeem
+func usableInvite(tokenHash, email string, now time.Time) bson.M {
+	return bson.M{
+		"tokenHash": tokenHash,
+		"usedAt":    bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": now},
+		"$or": bson.A{
+			bson.M{"email": bson.M{"$exists": false}},
+			bson.M{"email": email},
+		},
+	}
+}
+
+// GetUsableInvite returns the invite RedeemInvite would redeem, without using it;
+// mongo.ErrNoDocuments means there is none
+func (r *InviteRepository) GetUsableInvite(tokenHash, email string) (*models.Invite, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var invite models.Invite
+	if err := r.collection.FindOne(ctx, usableInvite(tokenHash, email, time.Now())).Decode(&invite); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// RedeemInvite marks the unused, unexpired invite with tokenHash as used by userID. It is
+// atomic, so an invite can only be redeemed once; mongo.ErrNoDocuments means there was
+// no such invite, or it was issued for another email address.
+func (r *InviteRepository) RedeemInvite(tokenHash, email, userID string) (*models.Invite, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := usableInvite(tokenHash, email, now)
+	update := bson.M{"$set": bson.M{"usedAt": now, "usedBy": userID}}
+
+	var invite models.Invite
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// ReleaseInvite makes a redeemed invite usable again, for when creating the account failed
+func (r *InviteRepository) ReleaseInvite(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{"usedAt": "", "usedBy": ""}})
+	return err
+}
+
+// DeleteInvite revokes an invite that hasn't been used
+func (r *InviteRepository) DeleteInvite(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "usedAt": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
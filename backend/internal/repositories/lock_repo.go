@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LockRepository hands out named leases so that only one server instance does a job at a time
+type LockRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLockRepository() *LockRepository {
+	return &LockRepository{
+		collection: database.DB.Collection("locks"),
+	}
+}
+
+// Acquire takes or renews the lease on name for owner until now+ttl. It reports false
+// while another owner holds an unexpired lease.
+func (r *LockRepository) Acquire(name, owner string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expiresAt": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expiresAt": now.Add(ttl)}}
+
+	// If the lease is held, the filter misses and the upsert collides with the existing _id
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Release gives up the lease on name if owner still holds it
+func (r *LockRepository) Release(name, owner string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+	return err
+}
+
+// GetLock returns the current lease on name, or nil if nobody holds it
+func (r *LockRepository) GetLock(name string) (*models.Lock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var lock models.Lock
+	err := r.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&lock)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
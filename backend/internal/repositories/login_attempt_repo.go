@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginAttemptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		collection: database.DB.Collection("login_attempts"),
+	}
+}
+
+func (r *LoginAttemptRepository) GetLoginAttempt(email string) (*models.LoginAttempt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var attempt models.LoginAttempt
+	if err := r.collection.FindOne(ctx, bson.M{"_id": email}).Decode(&attempt); err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RecordFailure counts a failed login and returns the new state. Failures older than
+// resetAfter are forgotten, so the count starts again from one.
+func (r *LoginAttemptRepository) RecordFailure(email string, resetAfter time.Duration) (*models.LoginAttempt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	// A pipeline update, so counting is atomic when requests race
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"failures": bson.M{"$cond": bson.A{
+			bson.M{"$gt": bson.A{"$lastFailureAt", now.Add(-resetAfter)}},
+			bson.M{"$add": bson.A{"$failures", 1}},
+			1,
+		}},
+		"lastFailureAt": now,
+	}}}}
+	var attempt models.LoginAttempt
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": email}, update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&attempt)
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// LockUntil locks an address out until the given time
+func (r *LoginAttemptRepository) LockUntil(email string, until time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": email}, bson.M{"$set": bson.M{"lockedUntil": until}})
+	return err
+}
+
+// ClearLoginAttempts forgets the failures of an address
+func (r *LoginAttemptRepository) ClearLoginAttempts(email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": email})
+	return err
+}
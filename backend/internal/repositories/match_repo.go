@@ -60,16 +60,18 @@ func (r *MatchRepository) GetMatchByID(id string) (*models.Match, error) {
 	return &match, nil
 }
 
-func (r *MatchRepository) GetMatchesByTeamID(teamID string) ([]models.Match, error) {
+// GetMatchesByTeamID returns a team's matches in a season, earliest first. An empty
+// seasonID looks across all seasons.
+func (r *MatchRepository) GetMatchesByTeamID(seasonID, teamID string) ([]models.Match, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	filter := bson.M{
+	filter := seasonFilter(seasonID, bson.M{
 		"$or": []bson.M{
 			{"homeTeamId": teamID},
 			{"awayTeamId": teamID},
 		},
-	}
+	})
 	opts := options.Find().SetSort(bson.M{"date": 1})
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -92,7 +94,8 @@ func (r *MatchRepository) UpdateMatch(match *models.Match) error {
 	return err
 }
 
-func (r *MatchRepository) GetStandings() ([]models.Standing, error) {
+// GetStandings returns a season's table, best placed first
+func (r *MatchRepository) GetStandings(seasonID string) ([]models.Standing, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -102,7 +105,7 @@ func (r *MatchRepository) GetStandings() ([]models.Standing, error) {
 		{Key: "goalDifference", Value: -1},
 		{Key: "goalsFor", Value: -1},
 	})
-	cursor, err := coll.Find(ctx, bson.M{}, opts)
+	cursor, err := coll.Find(ctx, bson.M{"seasonId": seasonID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +212,8 @@ func (r *MatchRepository) GetTeamByID(teamID string) (*models.Team, error) {
 }
 
 type StatEntry struct {
-	PlayerID  string `bson:"_id" json:"playerId"`
+	PlayerID  string `bson:"playerId" json:"playerId"`
+	SeasonID  string `bson:"seasonId" json:"seasonId"`
 	Name      string `bson:"name" json:"name"`
 	TeamName  string `bson:"teamName" json:"teamName"`
 	TeamID    string `bson:"teamId" json:"teamId"`
@@ -217,14 +221,14 @@ type StatEntry struct {
 	Value     int    `bson:"count" json:"value"`
 }
 
-func (r *MatchRepository) GetTopScorers(limit int) ([]StatEntry, error) {
+func (r *MatchRepository) GetTopScorers(seasonID string, limit int) ([]StatEntry, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	coll := database.DB.Collection("goalscorers")
 	opts := options.Find().SetSort(bson.M{"count": -1}).SetLimit(int64(limit))
 
-	cursor, err := coll.Find(ctx, bson.M{}, opts)
+	cursor, err := coll.Find(ctx, bson.M{"seasonId": seasonID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -238,14 +242,14 @@ func (r *MatchRepository) GetTopScorers(limit int) ([]StatEntry, error) {
 	return results, nil
 }
 
-func (r *MatchRepository) GetTopAssists(limit int) ([]StatEntry, error) {
+func (r *MatchRepository) GetTopAssists(seasonID string, limit int) ([]StatEntry, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	coll := database.DB.Collection("assists")
 	opts := options.Find().SetSort(bson.M{"count": -1}).SetLimit(int64(limit))
 
-	cursor, err := coll.Find(ctx, bson.M{}, opts)
+	cursor, err := coll.Find(ctx, bson.M{"seasonId": seasonID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -259,14 +263,14 @@ func (r *MatchRepository) GetTopAssists(limit int) ([]StatEntry, error) {
 	return results, nil
 }
 
-func (r *MatchRepository) GetCleanSheets(limit int) ([]StatEntry, error) {
+func (r *MatchRepository) GetCleanSheets(seasonID string, limit int) ([]StatEntry, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	coll := database.DB.Collection("cleansheets")
 	opts := options.Find().SetSort(bson.M{"count": -1}).SetLimit(int64(limit))
 
-	cursor, err := coll.Find(ctx, bson.M{}, opts)
+	cursor, err := coll.Find(ctx, bson.M{"seasonId": seasonID}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -339,13 +343,13 @@ func (r *MatchRepository) DeleteGoalEvent(eventID string) error {
 	return err
 }
 
-// GetMatchesByMatchday returns all matches for a given matchday
-func (r *MatchRepository) GetMatchesByMatchday(matchday int) ([]models.Match, error) {
+// GetMatchesByMatchday returns all matches of a season's matchday
+func (r *MatchRepository) GetMatchesByMatchday(seasonID string, matchday int) ([]models.Match, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
-	cursor, err := r.collection.Find(ctx, bson.M{"matchday": matchday}, opts)
+	cursor, err := r.collection.Find(ctx, bson.M{"seasonId": seasonID, "matchday": matchday}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -382,6 +386,49 @@ func (r *MatchRepository) UpdateMatchStatus(matchID string, status models.MatchS
 	return err
 }
 
+// SetMatchStatus changes the status of a match and records why
+func (r *MatchRepository) SetMatchStatus(matchID string, status models.MatchStatus, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": matchID},
+		bson.M{"$set": bson.M{"status": status, "statusReason": reason}},
+	)
+	return err
+}
+
+// RescheduleMatch moves a match to a new date and puts it back to SCHEDULED at 0-0
+func (r *MatchRepository) RescheduleMatch(matchID string, date, originalDate time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": matchID},
+		bson.M{
+			"$set": bson.M{
+				"date":         date,
+				"originalDate": originalDate,
+				"status":       models.MatchScheduled,
+				"homeScore":    0,
+				"awayScore":    0,
+			},
+			"$unset": bson.M{"statusReason": "", "halfTimeScore": "", "startedAt": ""},
+		},
+	)
+	return err
+}
+
+// DeleteMatchGoalEvents removes every goal event of a match
+func (r *MatchRepository) DeleteMatchGoalEvents(matchID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("goal_events")
+	_, err := coll.DeleteMany(ctx, bson.M{"matchId": matchID})
+	return err
+}
+
 // --- Player CRUD ---
 
 // CreatePlayer inserts a new player
@@ -414,8 +461,8 @@ func (r *MatchRepository) DeletePlayer(playerID string) error {
 	return err
 }
 
-// GetLatestResultMatches returns the last 'limit' matches with status FINISHED, sorted by date desc
-func (r *MatchRepository) GetLatestResultMatches(limit int) ([]models.Match, error) {
+// GetLatestResultMatches returns a season's last 'limit' matches with status FINISHED, latest matchday first
+func (r *MatchRepository) GetLatestResultMatches(seasonID string, limit int) ([]models.Match, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -426,7 +473,7 @@ func (r *MatchRepository) GetLatestResultMatches(limit int) ([]models.Match, err
 		}). // Latest first
 		SetLimit(int64(limit))
 
-	cursor, err := r.collection.Find(ctx, bson.M{"status": "FINISHED"}, opts)
+	cursor, err := r.collection.Find(ctx, seasonFilter(seasonID, bson.M{"status": "FINISHED"}), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -439,8 +486,9 @@ func (r *MatchRepository) GetLatestResultMatches(limit int) ([]models.Match, err
 	return matches, nil
 }
 
-// GetUpcomingMatches returns the next 'limit' matches with status SCHEDULED, sorted by date asc
-func (r *MatchRepository) GetUpcomingMatches(limit int) ([]models.Match, error) {
+// GetUpcomingMatches returns the next 'limit' matches with status SCHEDULED, sorted by date asc; a limit of 0 returns all of them.
+// An empty seasonID looks across all seasons.
+func (r *MatchRepository) GetUpcomingMatches(seasonID string, limit int) ([]models.Match, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -448,7 +496,7 @@ func (r *MatchRepository) GetUpcomingMatches(limit int) ([]models.Match, error)
 		SetSort(bson.D{{Key: "date", Value: 1}}). // Earliest first
 		SetLimit(int64(limit))
 
-	cursor, err := r.collection.Find(ctx, bson.M{"status": "SCHEDULED"}, opts)
+	cursor, err := r.collection.Find(ctx, seasonFilter(seasonID, bson.M{"status": "SCHEDULED"}), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -460,3 +508,338 @@ func (r *MatchRepository) GetUpcomingMatches(limit int) ([]models.Match, error)
 	}
 	return matches, nil
 }
+
+// GetAllGoalEvents returns every goal event of a season (all seasons when seasonID is empty),
+// sorted by matchday and minute
+func (r *MatchRepository) GetAllGoalEvents(seasonID string) ([]models.GoalEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("goal_events")
+	opts := options.Find().SetSort(bson.D{
+		{Key: "matchday", Value: 1},
+		{Key: "minute", Value: 1},
+	})
+	cursor, err := coll.Find(ctx, seasonFilter(seasonID, bson.M{}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.GoalEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetFinishedMatches returns a season's matches with status FINISHED (all seasons when
+// seasonID is empty), sorted by matchday and date
+func (r *MatchRepository) GetFinishedMatches(seasonID string) ([]models.Match, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "matchday", Value: 1},
+		{Key: "date", Value: 1},
+	})
+	cursor, err := r.collection.Find(ctx, seasonFilter(seasonID, bson.M{"status": models.MatchFinished}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// GetPlayerMatchEvents returns the non-goal match events (cards, shots, substitutions) recorded for a player
+func (r *MatchRepository) GetPlayerMatchEvents(playerID string) ([]models.MatchEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("match_events")
+	opts := options.Find().SetSort(bson.D{{Key: "minute", Value: 1}})
+	cursor, err := coll.Find(ctx, bson.M{"playerId": playerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.MatchEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetGoalkeepers returns every player whose position is goalkeeper
+func (r *MatchRepository) GetGoalkeepers() ([]models.Player, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("players")
+	cursor, err := coll.Find(ctx, bson.M{"position": bson.M{"$regex": "(?i)goalkeeper"}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// GetPlayersByPosition returns every player with the given position
+func (r *MatchRepository) GetPlayersByPosition(position string) ([]models.Player, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("players")
+	cursor, err := coll.Find(ctx, bson.M{"position": position})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var players []models.Player
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// UpdateMatchLineup stores the starting lineup of one side of a match
+func (r *MatchRepository) UpdateMatchLineup(matchID string, home bool, playerIDs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	field := "awayLineup"
+	if home {
+		field = "homeLineup"
+	}
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": matchID},
+		bson.M{"$set": bson.M{field: playerIDs}},
+	)
+	return err
+}
+
+// GetShotEvents returns the shots of a season, optionally only those of one match
+func (r *MatchRepository) GetShotEvents(seasonID, matchID string) ([]models.MatchEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := seasonFilter(seasonID, bson.M{"type": models.Shot})
+	if matchID != "" {
+		filter["matchId"] = matchID
+	}
+	coll := database.DB.Collection("match_events")
+	opts := options.Find().SetSort(bson.D{{Key: "minute", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var shots []models.MatchEvent
+	if err := cursor.All(ctx, &shots); err != nil {
+		return nil, err
+	}
+	return shots, nil
+}
+
+// GetSubstitutionEvents returns the substitutions of the given matches, in minute order
+func (r *MatchRepository) GetSubstitutionEvents(matchIDs []string) ([]models.MatchEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("match_events")
+	filter := bson.M{"type": models.Substitution, "matchId": bson.M{"$in": matchIDs}}
+	opts := options.Find().SetSort(bson.D{{Key: "minute", Value: 1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subs []models.MatchEvent
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// seasonFilter narrows a filter to one season; an empty seasonID leaves it unchanged
+func seasonFilter(seasonID string, filter bson.M) bson.M {
+	if seasonID != "" {
+		filter["seasonId"] = seasonID
+	}
+	return filter
+}
+
+// GetMatchesBySeason returns all matches of a season, sorted by matchday and date
+func (r *MatchRepository) GetMatchesBySeason(seasonID string) ([]models.Match, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: "matchday", Value: 1},
+		{Key: "date", Value: 1},
+	})
+	cursor, err := r.collection.Find(ctx, bson.M{"seasonId": seasonID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// GetPlayerSeasonStats returns the statistics of every player who featured in a season
+func (r *MatchRepository) GetPlayerSeasonStats(seasonID string) ([]models.PlayerSeasonStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("player_season_stats")
+	cursor, err := coll.Find(ctx, bson.M{"seasonId": seasonID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stats []models.PlayerSeasonStats
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetPlayerSeasonStatsByID returns one player's statistics for a season
+func (r *MatchRepository) GetPlayerSeasonStatsByID(seasonID, playerID string) (*models.PlayerSeasonStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("player_season_stats")
+	var stats models.PlayerSeasonStats
+	err := coll.FindOne(ctx, bson.M{"_id": models.SeasonScopedID(seasonID, playerID)}).Decode(&stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// CreateStandings adds a table row for each standing that does not exist yet
+func (r *MatchRepository) CreateStandings(standings []models.Standing) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	coll := database.DB.Collection("standings")
+	opts := options.Update().SetUpsert(true)
+	for _, st := range standings {
+		_, err := coll.UpdateOne(ctx, bson.M{"_id": st.ID}, bson.M{"$setOnInsert": st}, opts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateMatches inserts a batch of matches
+func (r *MatchRepository) CreateMatches(matches []models.Match) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	docs := make([]interface{}, len(matches))
+	for i := range matches {
+		docs[i] = matches[i]
+	}
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+// DeleteScheduledMatches removes the matches of a season that have not started yet
+func (r *MatchRepository) DeleteScheduledMatches(seasonID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := r.collection.DeleteMany(ctx, bson.M{"seasonId": seasonID, "status": models.MatchScheduled})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// GetScheduledBetween returns up to limit SCHEDULED matches of every season that kick
+// off after from and at or before to, earliest first. A zero time leaves that end open.
+func (r *MatchRepository) GetScheduledBetween(from, to time.Time, limit int64) ([]models.Match, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"status": models.MatchScheduled}
+	date := bson.M{}
+	if !from.IsZero() {
+		date["$gt"] = from
+	}
+	if !to.IsZero() {
+		date["$lte"] = to
+	}
+	if len(date) > 0 {
+		filter["date"] = date
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// GetLiveMatches returns every match currently LIVE
+func (r *MatchRepository) GetLiveMatches() ([]models.Match, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"status": models.MatchLive})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var matches []models.Match
+	if err := cursor.All(ctx, &matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// StartMatchIfScheduled moves a match from SCHEDULED to LIVE. It reports false if the
+// match was no longer SCHEDULED, so two callers can never both start the same match.
+func (r *MatchRepository) StartMatchIfScheduled(matchID string, startedAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": matchID, "status": models.MatchScheduled},
+		bson.M{"$set": bson.M{"status": models.MatchLive, "startedAt": startedAt}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type OIDCStateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOIDCStateRepository() *OIDCStateRepository {
+	return &OIDCStateRepository{
+		collection: database.DB.Collection("oidc_states"),
+	}
+}
+
+func (r *OIDCStateRepository) CreateOIDCState(state *models.OIDCState) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.InsertOne(ctx, state)
+	return err
+}
+
+// ConsumeOIDCState removes and returns the unexpired state with stateHash, so a callback
+// works once; mongo.ErrNoDocuments means there was no such state
+func (r *OIDCStateRepository) ConsumeOIDCState(stateHash string) (*models.OIDCState, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var state models.OIDCState
+	err := r.collection.FindOneAndDelete(ctx, bson.M{
+		"_id":       stateHash,
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}).Decode(&state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RefreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		collection: database.DB.Collection("refresh_tokens"),
+	}
+}
+
+func (r *RefreshTokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if token.ID.IsZero() {
+		token.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+func (r *RefreshTokenRepository) GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var token models.RefreshToken
+	if err := r.collection.FindOne(ctx, bson.M{"tokenHash": tokenHash}).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// UseRefreshToken marks a token used, replaced by the token with ID next. It reports
+// false if the token had already been used or revoked, so of two concurrent requests
+// presenting the same token only one wins.
+func (r *RefreshTokenRepository) UseRefreshToken(id, next primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":       id,
+		"usedAt":    bson.M{"$exists": false},
+		"revokedAt": bson.M{"$exists": false},
+	}
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"usedAt": time.Now(), "replacedBy": next}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// RevokeFamily revokes every token of a session
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) (int64, error) {
+	return r.revoke(bson.M{"familyId": familyID})
+}
+
+// RevokeUserTokens revokes every token of every session of a user
+func (r *RefreshTokenRepository) RevokeUserTokens(userID string) (int64, error) {
+	return r.revoke(bson.M{"userId": userID})
+}
+
+func (r *RefreshTokenRepository) revoke(filter bson.M) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter["revokedAt"] = bson.M{"$exists": false}
+	res, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
@@ -66,3 +66,15 @@ func (r *ReviewRepository) Delete(id string) error {
 	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
 	return err
 }
+
+// AnonymiseUser replaces the name on every review of a user; the reviews themselves stay
+func (r *ReviewRepository) AnonymiseUser(userID, name string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateMany(ctx, bson.M{"userId": userID}, bson.M{"$set": bson.M{"userName": name}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RoleChangeRepository stores the audit trail of role changes. Entries are never updated or deleted.
+type RoleChangeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRoleChangeRepository() *RoleChangeRepository {
+	return &RoleChangeRepository{
+		collection: database.DB.Collection("role_changes"),
+	}
+}
+
+func (r *RoleChangeRepository) CreateRoleChange(change *models.RoleChange) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if change.ID.IsZero() {
+		change.ID = primitive.NewObjectID()
+	}
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = time.Now()
+	}
+	_, err := r.collection.InsertOne(ctx, change)
+	return err
+}
+
+// GetRoleChanges returns the latest role changes, of one user if userID is set
+func (r *RoleChangeRepository) GetRoleChanges(userID string, limit int64) ([]models.RoleChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if userID != "" {
+		filter["userId"] = userID
+	}
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	changes := []models.RoleChange{}
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
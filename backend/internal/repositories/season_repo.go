@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SeasonRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSeasonRepository() *SeasonRepository {
+	return &SeasonRepository{
+		collection: database.DB.Collection("seasons"),
+	}
+}
+
+// GetAllSeasons returns every season, most recent first, without the archived final tables
+func (r *SeasonRepository) GetAllSeasons() ([]models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetProjection(bson.M{"finalTable": 0})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var seasons []models.Season
+	if err := cursor.All(ctx, &seasons); err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *SeasonRepository) GetSeasonByID(id string) (*models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var season models.Season
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// GetActiveSeason returns the season currently being played
+func (r *SeasonRepository) GetActiveSeason() (*models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var season models.Season
+	if err := r.collection.FindOne(ctx, bson.M{"isActive": true}).Decode(&season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// UpsertSeason creates or replaces a season
+func (r *SeasonRepository) UpsertSeason(season *models.Season) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": season.ID}, season, opts)
+	return err
+}
+
+// SetActiveSeason marks one season active and every other season inactive
+func (r *SeasonRepository) SetActiveSeason(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"isActive": true}}); err != nil {
+		return err
+	}
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$ne": id}},
+		bson.M{"$set": bson.M{"isActive": false}},
+	)
+	return err
+}
@@ -63,3 +63,21 @@ func (r *TeamRepository) UpdateTeamCoach(teamID, coachName string) error {
 	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": teamID}, bson.M{"$set": bson.M{"coach": coachName}})
 	return err
 }
+
+// UpdateTeamAliases replaces a team's aliases and external source IDs
+func (r *TeamRepository) UpdateTeamAliases(teamID string, aliases []string, externalIDs map[string]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": teamID},
+		bson.M{"$set": bson.M{"aliases": aliases, "externalIds": externalIDs}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
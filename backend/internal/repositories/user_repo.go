@@ -15,6 +15,9 @@ type UserRepository struct {
 	collection *mongo.Collection
 }
 
+// notDeleted is the deletedAt condition of users that haven't deleted their account
+var notDeleted = bson.M{"$exists": false}
+
 func NewUserRepository() *UserRepository {
 	return &UserRepository{
 		collection: database.DB.Collection("users"),
@@ -34,18 +37,20 @@ func (r *UserRepository) CreateUser(user *models.User) error {
 	return err
 }
 
+// GetUserByEmail finds an active user; deleted accounts are never returned
 func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	var user models.User
-	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	err := r.collection.FindOne(ctx, bson.M{"email": email, "deletedAt": notDeleted}).Decode(&user)
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
+// GetUserByID finds an active user; deleted accounts are never returned
 func (r *UserRepository) GetUserByID(id string) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -56,7 +61,7 @@ func (r *UserRepository) GetUserByID(id string) (*models.User, error) {
 	}
 
 	var user models.User
-	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&user)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "deletedAt": notDeleted}).Decode(&user)
 	if err != nil {
 		return nil, err
 	}
@@ -138,3 +143,203 @@ func (r *UserRepository) ToggleFavoritePlayer(userID, playerID string) error {
 	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
 	return err
 }
+
+// UpdateRole sets a user's role
+func (r *UserRepository) UpdateRole(id primitive.ObjectID, role string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"role": role, "updatedAt": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdatePassword stores a new password hash
+func (r *UserRepository) UpdatePassword(id primitive.ObjectID, hashed string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"password": hashed, "updatedAt": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// MarkEmailVerified records that the user confirmed email. It does nothing if the
+// user has changed their address since.
+func (r *UserRepository) MarkEmailVerified(id primitive.ObjectID, email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "email": email},
+		bson.M{"$set": bson.M{"emailVerifiedAt": now, "updatedAt": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateProfile saves a user's name, email address and whether the address is verified
+func (r *UserRepository) UpdateProfile(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	user.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{"fullName": user.FullName, "email": user.Email, "updatedAt": user.UpdatedAt}}
+	if user.EmailVerifiedAt == nil {
+		update["$unset"] = bson.M{"emailVerifiedAt": ""}
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": user.ID, "deletedAt": notDeleted}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SoftDelete marks a user deleted. The document stays for the audit trails that refer to it.
+func (r *UserRepository) SoftDelete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": notDeleted},
+		bson.M{"$set": bson.M{"deletedAt": now, "updatedAt": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// CountByRoles counts the active users that have any of roles
+func (r *UserRepository) CountByRoles(roles []string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.collection.CountDocuments(ctx, bson.M{"role": bson.M{"$in": roles}, "deletedAt": notDeleted})
+}
+
+// GetUserByIdentity finds the active user linked to subject at the OIDC provider issuer
+func (r *UserRepository) GetUserByIdentity(issuer, subject string) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{
+		"identities": bson.M{"$elemMatch": bson.M{"issuer": issuer, "subject": subject}},
+		"deletedAt":  notDeleted,
+	}).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// AddIdentity links a user to an account at an OIDC provider
+func (r *UserRepository) AddIdentity(id primitive.ObjectID, identity models.ExternalIdentity) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": notDeleted},
+		bson.M{
+			"$push": bson.M{"identities": identity},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetMFA saves a user's two-factor settings; nil turns two-factor authentication off
+func (r *UserRepository) SetMFA(id primitive.ObjectID, mfa *models.MFA) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"mfa": mfa, "updatedAt": time.Now()}}
+	if mfa == nil {
+		update = bson.M{"$unset": bson.M{"mfa": ""}, "$set": bson.M{"updatedAt": time.Now()}}
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "deletedAt": notDeleted}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UseMFAStep records that a TOTP code of step was used. It is atomic and only moves
+// forward, so a code works once; mongo.ErrNoDocuments means it was used already.
+func (r *UserRepository) UseMFAStep(id primitive.ObjectID, step int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "$or": bson.A{
+			bson.M{"mfa.lastStep": bson.M{"$lt": step}},
+			bson.M{"mfa.lastStep": bson.M{"$exists": false}},
+		}},
+		bson.M{"$set": bson.M{"mfa.lastStep": step}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UseRecoveryCode removes the recovery code with codeHash, so it works once;
+// mongo.ErrNoDocuments means the user has no such code
+func (r *UserRepository) UseRecoveryCode(id primitive.ObjectID, codeHash string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "mfa.recoveryCodes": codeHash},
+		bson.M{"$pull": bson.M{"mfa.recoveryCodes": codeHash}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -1,25 +1,71 @@
 package routes
 
 import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/handlers"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/middleware"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/ratelimit"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
+// Rate limits, per route group. Counts are per IP, or per account where brute force or
+// a single heavy user is the worry.
+var (
+	publicReadLimit = middleware.RateRule{Name: "public", Requests: 300, Window: time.Minute, Methods: []string{http.MethodGet}}
+	authIPLimit     = middleware.RateRule{Name: "auth-ip", Requests: 30, Window: time.Minute}
+	loginLimit      = middleware.RateRule{Name: "login", Requests: 10, Window: 15 * time.Minute, Key: middleware.ByAccount}
+	emailLimit      = middleware.RateRule{Name: "auth-email", Requests: 3, Window: time.Hour, Key: middleware.ByAccount}
+	accountLimit    = middleware.RateRule{Name: "account", Requests: 120, Window: time.Minute, Key: middleware.ByAccount}
+)
+
+// Audit targets of the staff routes
+var (
+	auditMatch     = middleware.AuditTarget{Type: "match", Collection: "matches", Param: "id"}
+	auditGoalEvent = middleware.AuditTarget{Type: "goal_event", Collection: "goal_events", Param: "eventId"}
+	auditPlayer    = middleware.AuditTarget{Type: "player", Collection: "players", Param: "id"}
+	auditTeam      = middleware.AuditTarget{Type: "team", Collection: "teams", Param: "id"}
+	auditReview    = middleware.AuditTarget{Type: "review", Collection: "reviews", Param: "id"}
+	auditSeason    = middleware.AuditTarget{Type: "season", Collection: "seasons"}
+	auditInvite    = middleware.AuditTarget{Type: "invite", Collection: "invites", Param: "id"}
+	auditUser      = middleware.AuditTarget{Type: "user", Collection: "users", Param: "id"}
+)
+
 func SetupRoutes(r *gin.Engine) {
 	api := r.Group("/api")
+	limits := newRateLimitStore(config.LoadConfig().RateLimitStore)
 
 	// Middleware
 	api.Use(middleware.CORSMiddleware())
+	api.Use(middleware.RateLimit(limits, publicReadLimit))
 
 	// Auth Routes
 	authHandler := handlers.NewAuthHandler()
-	auth := api.Group("/auth")
+	auth := api.Group("/auth", middleware.RateLimit(limits, authIPLimit))
 	{
 		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
+		auth.POST("/login", middleware.RateLimit(limits, loginLimit), authHandler.Login)
+		auth.POST("/invites/accept", middleware.RateLimit(limits, loginLimit), authHandler.AcceptInvite)
+		auth.POST("/refresh", authHandler.Refresh)
+		auth.POST("/logout", authHandler.Logout)
+		auth.POST("/logout-all", middleware.AuthMiddleware(), authHandler.LogoutAll)
+		auth.POST("/forgot-password", middleware.RateLimit(limits, emailLimit), authHandler.ForgotPassword)
+		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/verify-email/resend", middleware.AuthMiddleware(), middleware.RateLimit(limits, emailLimit), authHandler.ResendVerification)
+		auth.GET("/oidc/config", authHandler.OIDCConfig)
+		auth.GET("/oidc/login", authHandler.OIDCLogin)
+		auth.GET("/oidc/callback", authHandler.OIDCCallback)
+		auth.POST("/mfa/verify", authHandler.VerifyMFA)
+		auth.POST("/mfa/enroll", authHandler.EnrollPendingMFA)
+		auth.POST("/mfa/confirm", authHandler.ConfirmPendingMFA)
 	}
 
 	// Handlers
@@ -34,13 +80,29 @@ func SetupRoutes(r *gin.Engine) {
 	coachHandler := handlers.NewCoachHandler(coachService)
 
 	// Stats
-	statsService := services.NewStatsService(matchRepo)
+	statsService := services.NewStatsService(matchRepo, teamRepo)
 	statsHandler := handlers.NewStatsHandler(statsService)
 
+	// Analytics
+	analyticsService := services.NewAnalyticsService(matchRepo, teamRepo)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+
+	// Discipline
+	disciplineService := services.NewDisciplineService(matchRepo, teamRepo)
+	disciplineHandler := handlers.NewDisciplineHandler(disciplineService)
+
+	// Seasons
+	seasonHandler := handlers.NewSeasonHandler(services.NewSeasonService())
+
+	// Kickoff scheduler
+	schedulerHandler := handlers.NewSchedulerHandler()
+
 	// Public Routes
 	api.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
+	api.GET("/seasons", seasonHandler.GetSeasons)
+	api.GET("/seasons/:id", seasonHandler.GetSeason)
 	api.GET("/matches", footballHandler.GetMatches)
 	api.GET("/matches/:id", footballHandler.GetMatchByID)
 	api.GET("/standings", footballHandler.GetStandings)
@@ -48,8 +110,11 @@ func SetupRoutes(r *gin.Engine) {
 	api.GET("/teams/:id", footballHandler.GetTeamByID)
 	api.GET("/teams/:id/matches", footballHandler.GetTeamMatches)
 	api.GET("/teams/:id/squad", footballHandler.GetTeamSquad)
+	api.GET("/teams/:id/scoring-patterns", analyticsHandler.GetTeamScoringPatterns)
 	api.GET("/players", footballHandler.GetPlayers)
+	api.GET("/players/compare", footballHandler.ComparePlayers)
 	api.GET("/players/:id", footballHandler.GetPlayerByID)
+	api.GET("/players/:id/profile", footballHandler.GetPlayerProfile)
 	api.GET("/matches/results-json", footballHandler.GetResultsJSON)
 	api.GET("/matches/next-json", footballHandler.GetNextMatchesJSON)
 	api.GET("/matches/latest", footballHandler.GetLatestResults)
@@ -63,45 +128,88 @@ func SetupRoutes(r *gin.Engine) {
 		statsGroup.GET("/top-scorers", statsHandler.GetTopScorers)
 		statsGroup.GET("/top-assists", statsHandler.GetTopAssists)
 		statsGroup.GET("/clean-sheets", statsHandler.GetCleanSheets)
+		statsGroup.GET("/scoring-patterns", analyticsHandler.GetScoringPatterns)
+		statsGroup.GET("/partnerships", analyticsHandler.GetPartnerships)
+		statsGroup.GET("/xg/teams", statsHandler.GetTeamXG)
+		statsGroup.GET("/xg/players", statsHandler.GetPlayerXG)
 	}
+	api.GET("/discipline", disciplineHandler.GetDiscipline)
+	api.GET("/discipline/fair-play", disciplineHandler.GetFairPlay)
 	api.GET("/matches/:id/events", statsHandler.GetMatchEvents)
 	api.GET("/matches/:id/live-events", footballHandler.GetMatchEventsByID)
+	api.GET("/matches/:id/xg", statsHandler.GetMatchXG)
 
 	// Protected Routes (User)
 	userGroup := api.Group("/user")
-	userGroup.Use(middleware.AuthMiddleware())
+	userGroup.Use(middleware.AuthMiddleware(), middleware.RateLimit(limits, accountLimit))
 	{
+		userGroup.GET("/me", authHandler.GetMe)
+		userGroup.PATCH("/me", authHandler.UpdateMe)
+		userGroup.POST("/me/password", authHandler.ChangePassword)
+		userGroup.DELETE("/me", authHandler.DeleteMe)
+		userGroup.POST("/mfa/enroll", authHandler.EnrollMFA)
+		userGroup.POST("/mfa/confirm", authHandler.ConfirmMFA)
+		userGroup.DELETE("/mfa", authHandler.DisableMFA)
 		userGroup.GET("/favorites", authHandler.GetFavorites)
 		userGroup.POST("/favorites/teams/:id", authHandler.ToggleFavoriteTeam)
 		userGroup.POST("/favorites/players/:id", authHandler.ToggleFavoritePlayer)
+
+		apiKeyHandler := handlers.NewAPIKeyHandler()
+		userGroup.GET("/api-keys", apiKeyHandler.GetAPIKeys)
+		userGroup.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+		userGroup.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
 	}
 
-	// Admin Routes
-	admin := api.Group("/")
-	admin.Use(middleware.AdminMiddleware())
+	// Staff Routes: each declares the permission it needs, and API keys with the
+	// matching scope may use them. Every change is written to the audit log.
+	staff := api.Group("/")
+	staff.Use(middleware.AllowAPIKeyWrites(), middleware.AuthMiddleware(), middleware.RateLimit(limits, accountLimit))
 	{
 		// Match management
-		admin.POST("/matches", footballHandler.CreateMatch)
-		admin.PATCH("/matches/:id/status", footballHandler.UpdateMatchStatus)
-		admin.PATCH("/matches/:id/start", footballHandler.StartMatch)
-		admin.PATCH("/matches/:id/finish", footballHandler.FinishMatch)
+		matches := staff.Group("/", middleware.RequirePermission(models.PermManageMatches))
+		matches.POST("/matches", middleware.Audit("match.create", auditMatch), footballHandler.CreateMatch)
+		matches.PATCH("/matches/:id/status", middleware.Audit("match.status", auditMatch), footballHandler.UpdateMatchStatus)
+		matches.PATCH("/matches/:id/reschedule", middleware.Audit("match.reschedule", auditMatch), footballHandler.RescheduleMatch)
+		matches.PATCH("/matches/:id/start", middleware.Audit("match.start", auditMatch), footballHandler.StartMatch)
+		matches.PATCH("/matches/:id/finish", middleware.Audit("match.finish", auditMatch), footballHandler.FinishMatch)
+		matches.PUT("/matches/:id/lineup", middleware.Audit("match.lineup", auditMatch), footballHandler.SetLineup)
+		matches.POST("/matches/:id/substitutions", middleware.Audit("match.substitution", auditMatch), footballHandler.RecordSubstitution)
+		matches.POST("/matches/:id/cards", middleware.Audit("match.card", auditMatch), disciplineHandler.RecordCard)
+		matches.POST("/matches/:id/shots", middleware.Audit("match.shot", auditMatch), statsHandler.RecordShot)
 
 		// Event management (error correction)
-		admin.PUT("/matches/:id/events/:eventId", footballHandler.EditGoalEvent)
-		admin.DELETE("/matches/:id/events/:eventId", footballHandler.DeleteGoalEvent)
-
-		// Player management
-		admin.POST("/players", footballHandler.CreatePlayer)
-		admin.PUT("/players/:id", footballHandler.UpdatePlayer)
-		admin.DELETE("/players/:id", footballHandler.DeletePlayer)
-
-		// Review management
-		admin.DELETE("/reviews/:id", handlers.NewReviewHandler().DeleteReview)
-
-		// Coach management
-		admin.POST("/teams/:id/coach", coachHandler.AddCoach)
-		admin.DELETE("/teams/:id/coach", coachHandler.RemoveCoach)
-		admin.PUT("/teams/:id/coach/replace", coachHandler.ReplaceCoach)
+		matches.PUT("/matches/:id/events/:eventId", middleware.Audit("goal_event.update", auditGoalEvent), footballHandler.EditGoalEvent)
+		matches.DELETE("/matches/:id/events/:eventId", middleware.Audit("goal_event.delete", auditGoalEvent), footballHandler.DeleteGoalEvent)
+
+		// Player and coach management
+		players := staff.Group("/", middleware.RequirePermission(models.PermManagePlayers))
+		players.POST("/players", middleware.Audit("player.create", auditPlayer), footballHandler.CreatePlayer)
+		players.PUT("/players/:id", middleware.Audit("player.update", auditPlayer), footballHandler.UpdatePlayer)
+		players.DELETE("/players/:id", middleware.Audit("player.delete", auditPlayer), footballHandler.DeletePlayer)
+		players.POST("/teams/:id/coach", middleware.Audit("coach.add", auditTeam), coachHandler.AddCoach)
+		players.DELETE("/teams/:id/coach", middleware.Audit("coach.remove", auditTeam), coachHandler.RemoveCoach)
+		players.PUT("/teams/:id/coach/replace", middleware.Audit("coach.replace", auditTeam), coachHandler.ReplaceCoach)
+		players.PUT("/teams/:id/aliases", middleware.Audit("team.aliases", auditTeam), footballHandler.UpdateTeamAliases)
+
+		// Review moderation
+		staff.DELETE("/reviews/:id", middleware.RequirePermission(models.PermModerateReview), middleware.Audit("review.delete", auditReview), handlers.NewReviewHandler().DeleteReview)
+
+		// Season management and the kickoff scheduler
+		seasons := staff.Group("/", middleware.RequirePermission(models.PermManageSeasons))
+		seasons.POST("/seasons/rollover", middleware.Audit("season.rollover", auditSeason), seasonHandler.Rollover)
+		seasons.POST("/seasons/fixtures", middleware.Audit("season.fixtures", auditSeason), seasonHandler.GenerateFixtures)
+		seasons.GET("/scheduler/queue", schedulerHandler.GetQueue)
+
+		// Staff accounts
+		users := staff.Group("/", middleware.RequirePermission(models.PermManageUsers))
+		users.POST("/invites", middleware.Audit("invite.create", auditInvite), authHandler.CreateInvite)
+		users.GET("/invites", authHandler.GetInvites)
+		users.DELETE("/invites/:id", middleware.Audit("invite.revoke", auditInvite), authHandler.RevokeInvite)
+		users.PATCH("/users/:id/role", middleware.Audit("user.role", auditUser), authHandler.SetRole)
+		users.GET("/users/role-changes", authHandler.GetRoleChanges)
+
+		// Audit log of everything above
+		staff.GET("/admin/audit", middleware.RequirePermission(models.PermViewAudit), handlers.NewAuditHandler().GetAuditLog)
 	}
 
 	// Review Routes
@@ -109,8 +217,23 @@ func SetupRoutes(r *gin.Engine) {
 	api.GET("/reviews", reviewHandler.GetReviews)
 
 	reviewProtected := api.Group("/reviews")
-	reviewProtected.Use(middleware.AuthMiddleware())
+	reviewProtected.Use(middleware.AuthMiddleware(), middleware.RateLimit(limits, accountLimit))
 	{
 		reviewProtected.POST("/", reviewHandler.CreateReview)
 	}
 }
+
+// newRateLimitStore returns the store RATE_LIMIT_STORE names; nil turns rate limiting off
+func newRateLimitStore(kind string) ratelimit.Store {
+	switch kind {
+	case "mongo":
+		return ratelimit.NewMongoStore(database.DB.Collection("rate_limits"))
+	case "off":
+		return nil
+	case "memory", "":
+		return ratelimit.NewMemoryStore()
+	default:
+		log.Printf("Warning: RATE_LIMIT_STORE=%q is not memory, mongo or off, using memory", kind)
+		return ratelimit.NewMemoryStore()
+	}
+}
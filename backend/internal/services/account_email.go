@@ -0,0 +1,177 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/mailer"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidEmailToken    = errors.New("invalid or expired link")
+	ErrEmailNotVerified     = errors.New("email address not verified")
+	ErrEmailAlreadyVerified = errors.New("email address already verified")
+)
+
+const (
+	EmailVerifyTTL   = 48 * time.Hour
+	PasswordResetTTL = time.Hour
+)
+
+// NewMailer returns the mailer MAIL_DRIVER picks
+func NewMailer(cfg *config.Config) (mailer.Mailer, error) {
+	return mailer.New(mailer.Options{
+		Driver:   cfg.MailDriver,
+		From:     cfg.MailFrom,
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		Dir:      cfg.MailDir,
+		LogBody:  cfg.AppEnv == "development",
+	})
+}
+
+// SendVerificationEmail mails the user a new verification link; earlier links stop working
+func (s *AuthService) SendVerificationEmail(userID string) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+	return s.sendVerification(user)
+}
+
+func (s *AuthService) sendVerification(user *models.User) error {
+	return s.sendEmailToken(user, models.EmailTokenVerify, EmailVerifyTTL, "/verify-email",
+		"Confirm your email address",
+		"Welcome to the EPL app, %s.\n\nConfirm your email address to start posting reviews:\n%s\n\nThe link expires in %s.\n")
+}
+
+// VerifyEmail marks the address a verification link was sent to as confirmed
+func (s *AuthService) VerifyEmail(token string) error {
+	t, err := s.useEmailToken(token, models.EmailTokenVerify)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUserByID(t.UserID)
+	if err != nil {
+		return ErrInvalidEmailToken
+	}
+	if err := s.userRepo.MarkEmailVerified(user.ID, t.Email); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// The user has changed their address since the link was sent
+			return ErrInvalidEmailToken
+		}
+		return err
+	}
+	log.Printf("[Auth] %s verified", t.Email)
+	return nil
+}
+
+// ForgotPassword mails a password reset link. It succeeds whether or not the address has
+// an account, so the endpoint can't be used to find out which addresses do.
+func (s *AuthService) ForgotPassword(email string) error {
+	user, err := s.userRepo.GetUserByEmail(strings.TrimSpace(email))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		log.Printf("[Auth] Password reset requested for unknown address %s", email)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.sendEmailToken(user, models.EmailTokenReset, PasswordResetTTL, "/reset-password",
+		"Reset your password",
+		"Hi %s,\n\nSomeone asked to reset the password of your EPL account. If it was you, choose a new one here:\n%s\n\nThe link expires in %s. If it wasn't you, ignore this email.\n")
+}
+
+// ResetPassword sets a new password with a reset link. The link proves the user owns the
+// address, so it is verified too, and every session is signed out.
+func (s *AuthService) ResetPassword(token, password string) error {
+	t, err := s.useEmailToken(token, models.EmailTokenReset)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUserByID(t.UserID)
+	if err != nil || user.Email != t.Email {
+		return ErrInvalidEmailToken
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdatePassword(user.ID, string(hashed)); err != nil {
+		return err
+	}
+	if !user.EmailVerified() {
+		if err := s.userRepo.MarkEmailVerified(user.ID, user.Email); err != nil {
+			log.Printf("[Auth] Failed to mark %s verified: %v", user.Email, err)
+		}
+	}
+	if _, err := s.LogoutAll(user.ID.Hex()); err != nil {
+		log.Printf("[Auth] Failed to end the sessions of %s: %v", user.Email, err)
+	}
+	log.Printf("[Auth] %s reset their password", user.Email)
+	return nil
+}
+
+// sendEmailToken stores a new single-use token for purpose, replacing the user's unused
+// ones, and mails its link. body is formatted with the user's name, the link and the TTL.
+func (s *AuthService) sendEmailToken(user *models.User, purpose string, ttl time.Duration, path, subject, body string) error {
+	if err := s.emailTokens.DeleteUserTokens(user.ID.Hex(), purpose); err != nil {
+		return err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	if err := s.emailTokens.CreateEmailToken(&models.EmailToken{
+		TokenHash: hashToken(token),
+		UserID:    user.ID.Hex(),
+		Email:     user.Email,
+		Purpose:   purpose,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}); err != nil {
+		return err
+	}
+
+	link := strings.TrimRight(config.LoadConfig().AppURL, "/") + path + "?token=" + token
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    fmt.Sprintf(body, user.FullName, link, hours(ttl)),
+	})
+}
+
+// hours formats a TTL for an email, e.g. "1 hour" or "48 hours"
+func hours(d time.Duration) string {
+	if n := int(d.Hours()); n != 1 {
+		return fmt.Sprintf("%d hours", n)
+	}
+	return "1 hour"
+}
+
+func (s *AuthService) useEmailToken(token, purpose string) (*models.EmailToken, error) {
+	t, err := s.emailTokens.UseEmailToken(hashToken(token), purpose)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvalidEmailToken
+	}
+	return t, err
+}
@@ -0,0 +1,471 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+)
+
+// LateWinnerMinute is the minute after which a winning goal counts as a late winner
+const LateWinnerMinute = 85
+
+type AnalyticsService struct {
+	matchRepo *repositories.MatchRepository
+	teamRepo  *repositories.TeamRepository
+}
+
+func NewAnalyticsService(matchRepo *repositories.MatchRepository, teamRepo *repositories.TeamRepository) *AnalyticsService {
+	return &AnalyticsService{
+		matchRepo: matchRepo,
+		teamRepo:  teamRepo,
+	}
+}
+
+type MinuteBucket struct {
+	Label     string `json:"label"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Goals     int    `json:"goals"`
+	HomeGoals int    `json:"homeGoals"`
+	AwayGoals int    `json:"awayGoals"`
+	Conceded  int    `json:"conceded,omitempty"` // Only filled for team-scoped patterns
+}
+
+type FirstGoalStats struct {
+	Matches       int     `json:"matches"` // Matches in which at least one goal was scored
+	Wins          int     `json:"wins"`    // First scorer went on to win
+	Draws         int     `json:"draws"`
+	Losses        int     `json:"losses"`
+	WinPercentage float64 `json:"winPercentage"`
+}
+
+type LateWinner struct {
+	MatchID    string `json:"matchId"`
+	Matchday   int    `json:"matchday"`
+	HomeTeamID string `json:"homeTeamId"`
+	AwayTeamID string `json:"awayTeamId"`
+	HomeTeam   string `json:"homeTeam"`
+	AwayTeam   string `json:"awayTeam"`
+	HomeScore  int    `json:"homeScore"`
+	AwayScore  int    `json:"awayScore"`
+	TeamID     string `json:"teamId"`
+	TeamName   string `json:"teamName"`
+	ScorerID   string `json:"scorerId"`
+	ScorerName string `json:"scorerName"`
+	Minute     int    `json:"minute"`
+}
+
+type Partnership struct {
+	ScorerID   string `json:"scorerId"`
+	ScorerName string `json:"scorerName"`
+	AssistID   string `json:"assistId"`
+	AssistName string `json:"assistName"`
+	TeamID     string `json:"teamId"`
+	TeamName   string `json:"teamName"`
+	Goals      int    `json:"goals"`
+}
+
+type TeamScoringPattern struct {
+	TeamID                    string         `json:"teamId"`
+	TeamName                  string         `json:"teamName"`
+	MatchesAnalysed           int            `json:"matchesAnalysed"`
+	ScoredFirst               FirstGoalStats `json:"scoredFirst"`
+	ConcededFirst             FirstGoalStats `json:"concededFirst"`
+	Comebacks                 int            `json:"comebacks"` // Wins after trailing
+	PointsFromLosingPositions int            `json:"pointsFromLosingPositions"`
+	LateWinners               int            `json:"lateWinners"`
+	LateWinnersConceded       int            `json:"lateWinnersConceded"`
+}
+
+type ScoringPatternsResponse struct {
+	MatchesAnalysed           int                  `json:"matchesAnalysed"`
+	MatchesSkipped            int                  `json:"matchesSkipped"` // Finished matches whose goal events don't add up to the score
+	GoalsByMinute             []MinuteBucket       `json:"goalsByMinute"`
+	FirstGoal                 FirstGoalStats       `json:"firstGoal"`
+	Comebacks                 int                  `json:"comebacks"`
+	PointsFromLosingPositions int                  `json:"pointsFromLosingPositions"`
+	LateWinners               []LateWinner         `json:"lateWinners"`
+	Teams                     []TeamScoringPattern `json:"teams,omitempty"`
+}
+
+// matchTimeline is a finished match together with its goal events in minute order
+type matchTimeline struct {
+	match models.Match
+	goals []models.GoalEvent
+}
+
+// timelineSide is the outcome of a timeline from one team's point of view
+type timelineSide struct {
+	teamID     string
+	scored     int
+	conceded   int
+	trailed    bool
+	scoredGoal bool // Scored the first goal of the match
+	lateWinner *models.GoalEvent
+}
+
+// buildTimelines links goal events to finished matches.
+// Simulated events carry the match ID; seeded events only carry matchday and team.
+// Matches whose events don't reproduce the final score are returned separately.
+func buildTimelines(matches []models.Match, events []models.GoalEvent) ([]matchTimeline, []models.Match) {
+	byID := make(map[string][]models.GoalEvent)
+	byDayTeam := make(map[string][]models.GoalEvent)
+	for _, e := range events {
+		if e.MatchID != "" {
+			byID[e.MatchID] = append(byID[e.MatchID], e)
+		} else {
+			key := dayTeamKey(e.Matchday, e.TeamID)
+			byDayTeam[key] = append(byDayTeam[key], e)
+		}
+	}
+
+	var timelines []matchTimeline
+	var skipped []models.Match
+	for _, m := range matches {
+		goals := append([]models.GoalEvent{}, byID[m.ID]...)
+		if len(goals) == 0 {
+			goals = append(goals, byDayTeam[dayTeamKey(m.Matchday, m.HomeTeamID)]...)
+			goals = append(goals, byDayTeam[dayTeamKey(m.Matchday, m.AwayTeamID)]...)
+		}
+		sort.SliceStable(goals, func(i, j int) bool { return goals[i].Minute < goals[j].Minute })
+
+		home, away := 0, 0
+		for _, g := range goals {
+			if g.IsHomeGoal {
+				home++
+			} else {
+				away++
+			}
+		}
+		if home != m.HomeScore || away != m.AwayScore {
+			skipped = append(skipped, m)
+			continue
+		}
+		timelines = append(timelines, matchTimeline{match: m, goals: goals})
+	}
+	return timelines, skipped
+}
+
+func dayTeamKey(matchday int, teamID string) string {
+	return fmt.Sprintf("%s#%d", teamID, matchday)
+}
+
+// sides replays the timeline and returns the home and away perspectives
+func (t matchTimeline) sides() (timelineSide, timelineSide) {
+	home := timelineSide{teamID: t.match.HomeTeamID}
+	away := timelineSide{teamID: t.match.AwayTeamID}
+
+	// winningGoal is the goal that put the eventual winner ahead for the last time
+	var winningGoal *models.GoalEvent
+	h, a := 0, 0
+	for i := range t.goals {
+		g := t.goals[i]
+		if g.IsHomeGoal {
+			h++
+			if h == a+1 {
+				winningGoal = &t.goals[i]
+			}
+		} else {
+			a++
+			if a == h+1 {
+				winningGoal = &t.goals[i]
+			}
+		}
+		if i == 0 {
+			home.scoredGoal = g.IsHomeGoal
+			away.scoredGoal = !g.IsHomeGoal
+		}
+		if h < a {
+			home.trailed = true
+		}
+		if a < h {
+			away.trailed = true
+		}
+	}
+
+	home.scored, home.conceded = h, a
+	away.scored, away.conceded = a, h
+
+	if winningGoal != nil && winningGoal.Minute > LateWinnerMinute {
+		if h > a && winningGoal.IsHomeGoal {
+			home.lateWinner = winningGoal
+		} else if a > h && !winningGoal.IsHomeGoal {
+			away.lateWinner = winningGoal
+		}
+	}
+	return home, away
+}
+
+func (s timelineSide) points() int {
+	switch {
+	case s.scored > s.conceded:
+		return 3
+	case s.scored == s.conceded:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (f *FirstGoalStats) add(side timelineSide) {
+	f.Matches++
+	switch side.points() {
+	case 3:
+		f.Wins++
+	case 1:
+		f.Draws++
+	default:
+		f.Losses++
+	}
+}
+
+func (f *FirstGoalStats) finalize() {
+	if f.Matches > 0 {
+		f.WinPercentage = roundTo(float64(f.Wins)*100/float64(f.Matches), 1)
+	}
+}
+
+func newMinuteBuckets() []MinuteBucket {
+	return []MinuteBucket{
+		{Label: "1-15", From: 1, To: 15},
+		{Label: "16-30", From: 16, To: 30},
+		{Label: "31-45", From: 31, To: 45},
+		{Label: "46-60", From: 46, To: 60},
+		{Label: "61-75", From: 61, To: 75},
+		{Label: "76-90", From: 76, To: 90},
+		{Label: "90+", From: 91, To: 0},
+	}
+}
+
+func bucketIndex(minute int) int {
+	if minute > 90 {
+		return 6
+	}
+	if minute < 1 {
+		return 0
+	}
+	return (minute - 1) / 15
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+func (s *AnalyticsService) loadTimelines(seasonID string) ([]matchTimeline, []models.Match, map[string]string, error) {
+	matches, err := s.matchRepo.GetFinishedMatches(seasonID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	events, err := s.matchRepo.GetAllGoalEvents(seasonID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	teams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	teamNames := make(map[string]string)
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+	}
+
+	timelines, skipped := buildTimelines(matches, events)
+	return timelines, skipped, teamNames, nil
+}
+
+// GetScoringPatterns aggregates goal timing and game-state analytics.
+// With an empty teamID it covers the whole league and includes a per-team table.
+func (s *AnalyticsService) GetScoringPatterns(season, teamID string) (*ScoringPatternsResponse, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	timelines, skipped, teamNames, err := s.loadTimelines(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ScoringPatternsResponse{
+		GoalsByMinute: newMinuteBuckets(),
+		LateWinners:   []LateWinner{},
+	}
+	for _, m := range skipped {
+		if teamID == "" || m.HomeTeamID == teamID || m.AwayTeamID == teamID {
+			resp.MatchesSkipped++
+		}
+	}
+
+	teamPatterns := make(map[string]*TeamScoringPattern)
+	patternFor := func(id string) *TeamScoringPattern {
+		p, ok := teamPatterns[id]
+		if !ok {
+			p = &TeamScoringPattern{TeamID: id, TeamName: teamNames[id]}
+			teamPatterns[id] = p
+		}
+		return p
+	}
+
+	for _, t := range timelines {
+		m := t.match
+		if teamID != "" && m.HomeTeamID != teamID && m.AwayTeamID != teamID {
+			continue
+		}
+		resp.MatchesAnalysed++
+
+		for _, g := range t.goals {
+			b := &resp.GoalsByMinute[bucketIndex(g.Minute)]
+			if teamID != "" {
+				if g.TeamID == teamID {
+					b.Goals++
+				} else {
+					b.Conceded++
+				}
+			} else {
+				b.Goals++
+			}
+			if g.IsHomeGoal {
+				b.HomeGoals++
+			} else {
+				b.AwayGoals++
+			}
+		}
+
+		home, away := t.sides()
+		for _, side := range []timelineSide{home, away} {
+			if teamID != "" && side.teamID != teamID {
+				continue
+			}
+			p := patternFor(side.teamID)
+			p.MatchesAnalysed++
+			if len(t.goals) > 0 {
+				if side.scoredGoal {
+					p.ScoredFirst.add(side)
+				} else {
+					p.ConcededFirst.add(side)
+				}
+			}
+			if side.trailed {
+				if side.points() == 3 {
+					p.Comebacks++
+				}
+				p.PointsFromLosingPositions += side.points()
+			}
+			if side.lateWinner != nil {
+				p.LateWinners++
+			} else if side.scored < side.conceded && (home.lateWinner != nil || away.lateWinner != nil) {
+				p.LateWinnersConceded++
+			}
+		}
+
+		if len(t.goals) > 0 {
+			first := home
+			if away.scoredGoal {
+				first = away
+			}
+			if teamID == "" || first.teamID == teamID {
+				resp.FirstGoal.add(first)
+			}
+		}
+
+		for _, side := range []timelineSide{home, away} {
+			if side.lateWinner == nil || (teamID != "" && side.teamID != teamID) {
+				continue
+			}
+			g := side.lateWinner
+			resp.LateWinners = append(resp.LateWinners, LateWinner{
+				MatchID:    m.ID,
+				Matchday:   m.Matchday,
+				HomeTeamID: m.HomeTeamID,
+				AwayTeamID: m.AwayTeamID,
+				HomeTeam:   teamNames[m.HomeTeamID],
+				AwayTeam:   teamNames[m.AwayTeamID],
+				HomeScore:  m.HomeScore,
+				AwayScore:  m.AwayScore,
+				TeamID:     side.teamID,
+				TeamName:   teamNames[side.teamID],
+				ScorerID:   g.ScorerID,
+				ScorerName: g.ScorerName,
+				Minute:     g.Minute,
+			})
+		}
+	}
+	resp.FirstGoal.finalize()
+
+	for _, p := range teamPatterns {
+		p.ScoredFirst.finalize()
+		p.ConcededFirst.finalize()
+		resp.Comebacks += p.Comebacks
+		resp.PointsFromLosingPositions += p.PointsFromLosingPositions
+		resp.Teams = append(resp.Teams, *p)
+	}
+	sort.Slice(resp.Teams, func(i, j int) bool {
+		if resp.Teams[i].PointsFromLosingPositions != resp.Teams[j].PointsFromLosingPositions {
+			return resp.Teams[i].PointsFromLosingPositions > resp.Teams[j].PointsFromLosingPositions
+		}
+		return resp.Teams[i].TeamName < resp.Teams[j].TeamName
+	})
+	sort.Slice(resp.LateWinners, func(i, j int) bool {
+		if resp.LateWinners[i].Matchday != resp.LateWinners[j].Matchday {
+			return resp.LateWinners[i].Matchday < resp.LateWinners[j].Matchday
+		}
+		return resp.LateWinners[i].Minute < resp.LateWinners[j].Minute
+	})
+
+	return resp, nil
+}
+
+// GetPartnerships returns the most frequent scorer-assister pairs.
+// Unlike the game-state analytics this uses every goal event, linked to a match or not.
+func (s *AnalyticsService) GetPartnerships(season, teamID string, limit int) ([]Partnership, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.matchRepo.GetAllGoalEvents(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]*Partnership)
+	for _, e := range events {
+		if e.AssistID == "" || e.ScorerID == "" {
+			continue
+		}
+		if teamID != "" && e.TeamID != teamID {
+			continue
+		}
+		key := e.ScorerID + "|" + e.AssistID
+		p, ok := pairs[key]
+		if !ok {
+			p = &Partnership{
+				ScorerID:   e.ScorerID,
+				ScorerName: e.ScorerName,
+				AssistID:   e.AssistID,
+				AssistName: e.AssistName,
+				TeamID:     e.TeamID,
+				TeamName:   e.TeamName,
+			}
+			pairs[key] = p
+		}
+		p.Goals++
+	}
+
+	result := make([]Partnership, 0, len(pairs))
+	for _, p := range pairs {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Goals != result[j].Goals {
+			return result[i].Goals > result[j].Goals
+		}
+		return result[i].ScorerName < result[j].ScorerName
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrInvalidAPIKey        = errors.New("invalid, expired or revoked API key")
+	ErrInvalidAPIKeyRequest = errors.New("invalid API key request")
+	ErrAPIKeyNeedsMFA       = errors.New("the key's owner must set up two-factor authentication before their API keys work")
+)
+
+const (
+	DefaultAPIKeyTTL = 90 * 24 * time.Hour
+	MaxAPIKeyTTL     = 365 * 24 * time.Hour
+
+	apiKeyPrefix = "epl_"
+)
+
+type APIKeyService struct {
+	keys     *repositories.APIKeyRepository
+	userRepo *repositories.UserRepository
+}
+
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{
+		keys:     repositories.NewAPIKeyRepository(),
+		userRepo: repositories.NewUserRepository(),
+	}
+}
+
+// CreateAPIKey issues a key for userID with the given scopes (read if none). A scope
+// needs the permission it grants in the user's role. The key is only returned here.
+func (s *APIKeyService) CreateAPIKey(userID, name string, scopes []string, ttl time.Duration) (*models.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidAPIKeyRequest)
+	}
+	if ttl <= 0 {
+		ttl = DefaultAPIKeyTTL
+	}
+	if ttl > MaxAPIKeyTTL {
+		return nil, "", fmt.Errorf("%w: keys last at most %d days", ErrInvalidAPIKeyRequest, int(MaxAPIKeyTTL.Hours()/24))
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, "", mongo.ErrNoDocuments
+	}
+	if len(scopes) == 0 {
+		scopes = []string{models.ScopeRead}
+	}
+	seen := make(map[string]bool)
+	unique := scopes[:0:0]
+	for _, scope := range scopes {
+		if !models.ValidScope(scope) {
+			return nil, "", fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKeyRequest, scope)
+		}
+		if scope == models.ScopeMatchOps && !models.RoleHas(user.Role, models.PermManageMatches) {
+			return nil, "", fmt.Errorf("%w: your role can't manage matches", ErrInvalidAPIKeyRequest)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    secret[:len(apiKeyPrefix)+8],
+		KeyHash:   hashToken(secret),
+		UserID:    userID,
+		Scopes:    unique,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.keys.CreateAPIKey(key); err != nil {
+		return nil, "", err
+	}
+	log.Printf("[Auth] %s created API key %q (%s) with scopes %v", user.Email, key.Name, key.Prefix, key.Scopes)
+	return key, secret, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(userID string) ([]models.APIKey, error) {
+	return s.keys.GetUserAPIKeys(userID)
+}
+
+func (s *APIKeyService) RevokeAPIKey(userID, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	return s.keys.RevokeAPIKey(objID, userID)
+}
+
+// Authenticate returns the key and its owner if the key is valid, and records its use.
+// A deleted owner's keys stop working, and so do the keys of an owner whose role has
+// since been made to require two-factor authentication until they set it up.
+func (s *APIKeyService) Authenticate(secret, ip string) (*models.APIKey, *models.User, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	key, err := s.keys.GetAPIKeyByHash(hashToken(secret))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if key.RevokedAt != nil || time.Now().After(key.ExpiresAt) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	user, err := s.userRepo.GetUserByID(key.UserID)
+	if err != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if mfaRequired(user.Role) && !user.MFAEnabled() {
+		return nil, nil, ErrAPIKeyNeedsMFA
+	}
+
+	if err := s.keys.TouchAPIKey(key.ID, ip); err != nil {
+		log.Printf("[Auth] Failed to record the use of API key %s: %v", key.Prefix, err)
+	}
+	return key, user, nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// redactedFields never reach the audit log, at any depth
+var redactedFields = map[string]bool{
+	"password":  true,
+	"tokenHash": true,
+	"keyHash":   true,
+}
+
+// unauditedFields change on every write and say nothing about what was changed
+var unauditedFields = map[string]bool{
+	"updatedAt": true,
+}
+
+type AuditService struct {
+	auditRepo *repositories.AuditRepository
+}
+
+func NewAuditService() *AuditService {
+	return &AuditService{
+		auditRepo: repositories.NewAuditRepository(),
+	}
+}
+
+// Snapshot reads a document to diff; on failure the entry is written without it
+func (s *AuditService) Snapshot(collection, id string) bson.M {
+	doc, err := s.auditRepo.Snapshot(collection, id)
+	if err != nil {
+		log.Printf("[Audit] Failed to read %s %s: %v", collection, id, err)
+		return nil
+	}
+	return doc
+}
+
+// Record writes an entry with the changes between before and after. The change has
+// already happened, so a failure is logged rather than returned.
+func (s *AuditService) Record(entry *models.AuditEntry, before, after bson.M) {
+	entry.Changes = Diff(before, after)
+	entry.Request = redact(entry.Request)
+	entry.CreatedAt = time.Now()
+	if err := s.auditRepo.CreateAuditEntry(entry); err != nil {
+		log.Printf("[Audit] Failed to record %s of %s %s by %s: %v", entry.Action, entry.TargetType, entry.TargetID, entry.ActorEmail, err)
+	}
+}
+
+// GetAuditLog returns the latest entries matching filter
+func (s *AuditService) GetAuditLog(filter repositories.AuditFilter, limit int64) ([]models.AuditEntry, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.auditRepo.GetAuditEntries(filter, limit)
+}
+
+// Diff lists the top-level fields that differ between two versions of a document. A
+// created document has no before, a deleted one no after.
+func Diff(before, after bson.M) map[string]models.AuditChange {
+	changes := make(map[string]models.AuditChange)
+	for field, from := range before {
+		if unauditedFields[field] {
+			continue
+		}
+		to, ok := after[field]
+		if !ok || !reflect.DeepEqual(from, to) {
+			changes[field] = models.AuditChange{From: redactValue(field, from), To: redactValue(field, to)}
+		}
+	}
+	for field, to := range after {
+		if _, ok := before[field]; ok || unauditedFields[field] {
+			continue
+		}
+		changes[field] = models.AuditChange{To: redactValue(field, to)}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func redactValue(field string, v interface{}) interface{} {
+	if redactedFields[field] && v != nil {
+		return "[redacted]"
+	}
+	if m, ok := v.(bson.M); ok {
+		return redact(m)
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return redact(m)
+	}
+	return v
+}
+
+func redact(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = redactValue(k, v)
+	}
+	return out
+}
@@ -1,67 +1,115 @@
 package services
 
 import (
-	"errors"
+	"log"
 
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/mailer"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/oidc"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
-	"github.com/Sanat-07/English-Premier-League/backend/internal/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
-	userRepo *repositories.UserRepository
+	userRepo      *repositories.UserRepository
+	inviteRepo    *repositories.InviteRepository
+	roleChanges   *repositories.RoleChangeRepository
+	refreshTokens *repositories.RefreshTokenRepository
+	emailTokens   *repositories.EmailTokenRepository
+	reviews       *repositories.ReviewRepository
+	apiKeys       *repositories.APIKeyRepository
+	loginAttempts loginAttemptStore
+	oidcStates    *repositories.OIDCStateRepository
+	mfaCodes      mfaCodeStore
+	mailer        mailer.Mailer
+	oidc          *oidc.Provider // nil unless single sign-on is configured
 }
 
 func NewAuthService() *AuthService {
+	cfg := config.LoadConfig()
+	m, err := NewMailer(cfg)
+	if err != nil {
+		log.Printf("[Auth] %v; emails will only be logged", err)
+		m = &mailer.FileMailer{LogBody: cfg.AppEnv == "development"}
+	}
+	provider, err := NewOIDCProvider(cfg)
+	if err != nil {
+		log.Printf("[Auth] %v; single sign-on is off", err)
+	}
+	userRepo := repositories.NewUserRepository()
 	return &AuthService{
-		userRepo: repositories.NewUserRepository(),
+		userRepo:      userRepo,
+		inviteRepo:    repositories.NewInviteRepository(),
+		roleChanges:   repositories.NewRoleChangeRepository(),
+		refreshTokens: repositories.NewRefreshTokenRepository(),
+		emailTokens:   repositories.NewEmailTokenRepository(),
+		reviews:       repositories.NewReviewRepository(),
+		apiKeys:       repositories.NewAPIKeyRepository(),
+		loginAttempts: repositories.NewLoginAttemptRepository(),
+		oidcStates:    repositories.NewOIDCStateRepository(),
+		mfaCodes:      userRepo,
+		mailer:        m,
+		oidc:          provider,
 	}
 }
 
-func (s *AuthService) Register(email, password, fullName, role string) (string, error) {
-	// Validate role
-	if role != "ADMIN" && role != "USER" {
-		role = "USER" // Default to USER if invalid
-	}
-
+// Register creates a USER account. Admins are made with an invite or by the epl CLI.
+func (s *AuthService) Register(email, password, fullName string, client Client) (*TokenPair, error) {
 	// Check if user exists
 	existingUser, err := s.userRepo.GetUserByEmail(email)
 	if err == nil && !existingUser.ID.IsZero() {
-		return "", errors.New("user already exists")
+		return nil, ErrUserExists
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	newUser := &models.User{
 		Email:    email,
 		Password: string(hashedPassword),
 		FullName: fullName,
-		Role:     role,
+		Role:     models.RoleUser,
 	}
 
 	if err := s.userRepo.CreateUser(newUser); err != nil {
-		return "", err
+		return nil, err
+	}
+	if err := s.sendVerification(newUser); err != nil {
+		// The account works; the user can ask for another link
+		log.Printf("[Auth] Failed to send the verification email to %s: %v", email, err)
 	}
 
-	return utils.GenerateToken(newUser.ID.Hex(), newUser.Email, newUser.Role)
+	return s.startSession(newUser, client)
 }
 
-func (s *AuthService) Login(email, password string) (string, *models.User, error) {
+// Login checks the password of an account. Repeated failures lock the address out for a
+// while, whether or not it has an account. Users with two-factor authentication get an
+// MFAChallengeError instead of a session.
+func (s *AuthService) Login(email, password string, client Client) (*TokenPair, *models.User, error) {
+	if err := s.checkLockout(email); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := s.userRepo.GetUserByEmail(email)
 	if err != nil {
-		return "", nil, errors.New("invalid credentials")
+		s.recordLoginFailure(email)
+		return nil, nil, ErrInvalidCredentials
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, errors.New("invalid credentials")
+		s.recordLoginFailure(email)
+		return nil, nil, ErrInvalidCredentials
 	}
 
-	token, err := utils.GenerateToken(user.ID.Hex(), user.Email, user.Role)
-	return token, user, err
+	// With two-factor authentication the failures count until the code is right too
+	tokens, err := s.signIn(user, client)
+	if err == nil {
+		s.clearLoginFailures(email)
+	}
+	return tokens, user, err
 }
 
 func (s *AuthService) GetUserFavorites(userID string) ([]string, []string, error) {
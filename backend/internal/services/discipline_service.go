@@ -0,0 +1,429 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DisciplineRules are the league's suspension rules
+type DisciplineRules struct {
+	YellowCardThreshold      int `json:"yellowCardThreshold"`
+	YellowCardCutoffMatchday int `json:"yellowCardCutoffMatchday"`
+	RedCardBanMatches        int `json:"redCardBanMatches"`
+}
+
+var (
+	disciplineRules     DisciplineRules
+	disciplineRulesOnce sync.Once
+)
+
+// GetDisciplineRules returns the suspension rules from config
+func GetDisciplineRules() DisciplineRules {
+	disciplineRulesOnce.Do(func() {
+		cfg := config.LoadConfig()
+		disciplineRules = DisciplineRules{
+			YellowCardThreshold:      cfg.YellowCardThreshold,
+			YellowCardCutoffMatchday: cfg.YellowCardCutoffMatchday,
+			RedCardBanMatches:        cfg.RedCardBanMatches,
+		}
+	})
+	return disciplineRules
+}
+
+// SuspendedPlayerIDs returns the players of a team who are currently serving a ban in a season
+func SuspendedPlayerIDs(seasonID, teamID string) (map[string]bool, error) {
+	suspensions, err := repositories.NewDisciplineRepository().GetTeamSuspensions(seasonID, teamID, true)
+	if err != nil {
+		return nil, err
+	}
+	suspended := make(map[string]bool)
+	for _, s := range suspensions {
+		suspended[s.PlayerID] = true
+	}
+	return suspended, nil
+}
+
+// ProcessMatchDiscipline runs after a match is finished: suspended players of both teams
+// are credited with serving it, then new bans are issued from the match's cards. A
+// player reaching the yellow card threshold is banned once per season, from the match
+// of the card that reached it. Bans and yellow card counts don't carry over from one
+// season to the next.
+// It is safe to run more than once for the same match.
+func ProcessMatchDiscipline(ctx context.Context, matchID string) error {
+	disciplineRepo := repositories.NewDisciplineRepository()
+	matchRepo := repositories.NewMatchRepository()
+	rules := GetDisciplineRules()
+
+	match, err := matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return err
+	}
+
+	// 1. Serve existing bans
+	for _, teamID := range []string{match.HomeTeamID, match.AwayTeamID} {
+		active, err := disciplineRepo.GetTeamSuspensions(match.SeasonID, teamID, true)
+		if err != nil {
+			return err
+		}
+		for _, s := range active {
+			// Only bans picked up before this match can be served by it
+			if s.TriggerMatchID == matchID || !s.TriggerDate.Before(match.Date) {
+				continue
+			}
+			if err := disciplineRepo.MarkSuspensionServed(s.ID, matchID); err != nil {
+				log.Printf("[Discipline] Failed to mark suspension %s served: %v", s.ID, err)
+			}
+		}
+	}
+
+	// 2. Issue new bans
+	matchCards, err := disciplineRepo.GetMatchEvents(matchID)
+	if err != nil {
+		return err
+	}
+
+	booked := make(map[string]bool) // Players shown a yellow in this match
+	for _, e := range matchCards {
+		switch e.Type {
+		case models.RedCard:
+			if rules.RedCardBanMatches <= 0 {
+				continue
+			}
+			if err := disciplineRepo.CreateSuspension(newSuspension(e, match, models.SuspensionRedCard, rules.RedCardBanMatches)); err != nil {
+				log.Printf("[Discipline] Failed to create suspension for player %s: %v", e.PlayerID, err)
+			}
+		case models.YellowCard:
+			booked[e.PlayerID] = true
+		}
+	}
+	if len(booked) == 0 || match.Matchday > rules.YellowCardCutoffMatchday || rules.YellowCardThreshold <= 0 {
+		log.Printf("[Discipline] Processed match %s", matchID)
+		return nil
+	}
+
+	// A yellow recorded late on an earlier match, or a match played out of matchday
+	// order, can move the card that reaches the threshold, so each booked player's
+	// yellows are recounted in the order the matches were played
+	allCards, err := disciplineRepo.GetCardEvents(match.SeasonID)
+	if err != nil {
+		return err
+	}
+	seasonMatches, err := matchRepo.GetMatchesBySeason(match.SeasonID)
+	if err != nil {
+		return err
+	}
+	matches := make(map[string]*models.Match, len(seasonMatches))
+	for i := range seasonMatches {
+		matches[seasonMatches[i].ID] = &seasonMatches[i]
+	}
+	suspensions, err := disciplineRepo.GetSuspensions(match.SeasonID, false)
+	if err != nil {
+		return err
+	}
+	banned := make(map[string]bool) // Players already banned for yellows this season
+	for _, s := range suspensions {
+		if s.Reason == models.SuspensionYellowAccumulation {
+			banned[s.PlayerID] = true
+		}
+	}
+
+	yellows := make(map[string][]models.MatchEvent)
+	for _, c := range allCards {
+		if !booked[c.PlayerID] || banned[c.PlayerID] || c.Type != models.YellowCard || c.Matchday > rules.YellowCardCutoffMatchday {
+			continue
+		}
+		if _, ok := matches[c.MatchID]; ok {
+			yellows[c.PlayerID] = append(yellows[c.PlayerID], c)
+		}
+	}
+	for playerID, cards := range yellows {
+		if len(cards) < rules.YellowCardThreshold {
+			continue
+		}
+		sort.SliceStable(cards, func(i, j int) bool {
+			a, b := matches[cards[i].MatchID], matches[cards[j].MatchID]
+			if !a.Date.Equal(b.Date) {
+				return a.Date.Before(b.Date)
+			}
+			return cards[i].Minute < cards[j].Minute
+		})
+		trigger := cards[rules.YellowCardThreshold-1]
+		if err := disciplineRepo.CreateSuspension(newSuspension(trigger, matches[trigger.MatchID], models.SuspensionYellowAccumulation, 1)); err != nil {
+			log.Printf("[Discipline] Failed to create suspension for player %s: %v", playerID, err)
+		}
+	}
+
+	log.Printf("[Discipline] Processed match %s", matchID)
+	return nil
+}
+
+func newSuspension(e models.MatchEvent, match *models.Match, reason models.SuspensionReason, matches int) *models.Suspension {
+	return &models.Suspension{
+		ID:              fmt.Sprintf("%s_%s_%s", reason, e.PlayerID, match.ID),
+		PlayerID:        e.PlayerID,
+		PlayerName:      e.PlayerName,
+		TeamID:          e.TeamID,
+		SeasonID:        match.SeasonID,
+		Reason:          reason,
+		TriggerMatchID:  match.ID,
+		TriggerMatchday: match.Matchday,
+		TriggerDate:     match.Date,
+		Matches:         matches,
+		ServedMatchIDs:  []string{},
+		CreatedAt:       time.Now(),
+	}
+}
+
+type DisciplineService struct {
+	disciplineRepo *repositories.DisciplineRepository
+	matchRepo      *repositories.MatchRepository
+	teamRepo       *repositories.TeamRepository
+}
+
+func NewDisciplineService(matchRepo *repositories.MatchRepository, teamRepo *repositories.TeamRepository) *DisciplineService {
+	return &DisciplineService{
+		disciplineRepo: repositories.NewDisciplineRepository(),
+		matchRepo:      matchRepo,
+		teamRepo:       teamRepo,
+	}
+}
+
+type PlayerDiscipline struct {
+	PlayerID    string `json:"playerId"`
+	PlayerName  string `json:"playerName"`
+	TeamID      string `json:"teamId"`
+	TeamName    string `json:"teamName"`
+	YellowCards int    `json:"yellowCards"`
+	RedCards    int    `json:"redCards"`
+	Suspended   bool   `json:"suspended"`
+	// YellowsToBan is how many more yellows trigger a ban, nil once the cut-off has passed
+	YellowsToBan *int `json:"yellowsToBan"`
+}
+
+type ActiveSuspension struct {
+	models.Suspension
+	TeamName  string `json:"teamName"`
+	Remaining int    `json:"remaining"`
+}
+
+type DisciplineTable struct {
+	Rules       DisciplineRules    `json:"rules"`
+	Players     []PlayerDiscipline `json:"players"`
+	Suspensions []ActiveSuspension `json:"suspensions"`
+}
+
+type FairPlayEntry struct {
+	Position    int    `json:"position"`
+	TeamID      string `json:"teamId"`
+	TeamName    string `json:"teamName"`
+	YellowCards int    `json:"yellowCards"`
+	RedCards    int    `json:"redCards"`
+	Points      int    `json:"points"` // 1 per yellow, 3 per red; lower is better
+}
+
+func (s *DisciplineService) teamNames() (map[string]string, error) {
+	teams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+// GetDisciplineTable returns a season's card counts per player and the bans still to be
+// served; an empty season means the active one
+func (s *DisciplineService) GetDisciplineTable(season string) (*DisciplineTable, error) {
+	rules := GetDisciplineRules()
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	current, err := ResolveSeasonID("")
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.disciplineRepo.GetCardEvents(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.disciplineRepo.GetSuspensions(seasonID, true)
+	if err != nil {
+		return nil, err
+	}
+	teamNames, err := s.teamNames()
+	if err != nil {
+		return nil, err
+	}
+	// Yellows still count towards a ban only in the active season
+	activeMatchday := rules.YellowCardCutoffMatchday + 1
+	if seasonID == current {
+		if activeMatchday, err = NewFootballService().GetActiveMatchday(); err != nil {
+			return nil, err
+		}
+	}
+
+	table := &DisciplineTable{
+		Rules:       rules,
+		Players:     []PlayerDiscipline{},
+		Suspensions: []ActiveSuspension{},
+	}
+
+	suspended := make(map[string]bool)
+	for _, susp := range active {
+		suspended[susp.PlayerID] = true
+		table.Suspensions = append(table.Suspensions, ActiveSuspension{
+			Suspension: susp,
+			TeamName:   teamNames[susp.TeamID],
+			Remaining:  susp.Remaining(),
+		})
+	}
+
+	players := make(map[string]*PlayerDiscipline)
+	countedYellows := make(map[string]int)
+	for _, c := range cards {
+		p, ok := players[c.PlayerID]
+		if !ok {
+			p = &PlayerDiscipline{
+				PlayerID:   c.PlayerID,
+				PlayerName: c.PlayerName,
+				TeamID:     c.TeamID,
+				TeamName:   teamNames[c.TeamID],
+				Suspended:  suspended[c.PlayerID],
+			}
+			players[c.PlayerID] = p
+		}
+		if c.Type == models.RedCard {
+			p.RedCards++
+			continue
+		}
+		p.YellowCards++
+		if c.Matchday <= rules.YellowCardCutoffMatchday {
+			countedYellows[c.PlayerID]++
+		}
+	}
+
+	for id, p := range players {
+		if activeMatchday <= rules.YellowCardCutoffMatchday && countedYellows[id] < rules.YellowCardThreshold {
+			left := rules.YellowCardThreshold - countedYellows[id]
+			p.YellowsToBan = &left
+		}
+		table.Players = append(table.Players, *p)
+	}
+	sort.Slice(table.Players, func(i, j int) bool {
+		a, b := table.Players[i], table.Players[j]
+		if a.RedCards != b.RedCards {
+			return a.RedCards > b.RedCards
+		}
+		if a.YellowCards != b.YellowCards {
+			return a.YellowCards > b.YellowCards
+		}
+		return a.PlayerName < b.PlayerName
+	})
+
+	return table, nil
+}
+
+// GetFairPlayTable ranks teams by disciplinary points in a season, fewest first
+func (s *DisciplineService) GetFairPlayTable(season string) ([]FairPlayEntry, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.disciplineRepo.GetCardEvents(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	byTeam := make(map[string]*FairPlayEntry)
+	for _, t := range teams {
+		byTeam[t.ID] = &FairPlayEntry{TeamID: t.ID, TeamName: t.Name}
+	}
+	for _, c := range cards {
+		entry, ok := byTeam[c.TeamID]
+		if !ok {
+			continue
+		}
+		if c.Type == models.RedCard {
+			entry.RedCards++
+			entry.Points += 3
+		} else {
+			entry.YellowCards++
+			entry.Points++
+		}
+	}
+
+	table := make([]FairPlayEntry, 0, len(byTeam))
+	for _, entry := range byTeam {
+		table = append(table, *entry)
+	}
+	sort.Slice(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points < table[j].Points
+		}
+		return table[i].TeamName < table[j].TeamName
+	})
+	for i := range table {
+		table[i].Position = i + 1
+	}
+	return table, nil
+}
+
+// RecordCard books a player in a live or finished match
+func (s *DisciplineService) RecordCard(matchID, playerID string, cardType models.EventType, minute int) (*models.MatchEvent, error) {
+	if cardType != models.YellowCard && cardType != models.RedCard {
+		return nil, fmt.Errorf("invalid card type: %s", cardType)
+	}
+	match, err := s.matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.Status != models.MatchLive && match.Status != models.MatchFinished {
+		return nil, fmt.Errorf("cards can only be recorded for LIVE or FINISHED matches (current: %s)", match.Status)
+	}
+	player, err := s.matchRepo.GetPlayerByID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+		return nil, fmt.Errorf("player %s does not play for either team", player.Name)
+	}
+
+	event := &models.MatchEvent{
+		ID:         primitive.NewObjectID().Hex(),
+		MatchID:    matchID,
+		PlayerID:   playerID,
+		PlayerName: player.Name,
+		TeamID:     player.TeamID,
+		SeasonID:   match.SeasonID,
+		Matchday:   match.Matchday,
+		Type:       cardType,
+		Minute:     minute,
+	}
+	if err := s.disciplineRepo.CreateMatchEvent(event); err != nil {
+		return nil, err
+	}
+
+	// Late corrections to a finished match still count towards bans
+	if match.Status == models.MatchFinished {
+		if err := ProcessMatchDiscipline(context.Background(), matchID); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+)
+
+// MaxConsecutiveVenue is the longest run of home or away games a club may have
+const MaxConsecutiveVenue = 2
+
+var ErrInvalidFixtures = errors.New("invalid fixture request")
+
+type GeneratedFixture struct {
+	Matchday   int       `json:"matchday"`
+	Date       time.Time `json:"date"`
+	HomeTeamID string    `json:"homeTeamId"`
+	AwayTeamID string    `json:"awayTeamId"`
+}
+
+type FixtureOptions struct {
+	StartDate   time.Time // Kick-off of matchday 1; later matchdays follow every DaysBetween days
+	DaysBetween int       // Defaults to 7
+	Seed        int64     // Same seed and teams give the same fixture list
+	// Pairs are clubs that must never be at home on the same day, on top of the clubs
+	// that share a stadium or a city
+	Pairs [][2]string
+}
+
+// GenerateRoundRobin builds a double round-robin in which every club plays every other
+// club home and away. It uses the circle method: the first half is a single round-robin
+// in which clubs alternate home and away, the second half repeats it with venues swapped.
+// No club has more than two home or away games in a row, and paired clubs are given
+// exactly opposite home/away patterns so they are never at home on the same day.
+func GenerateRoundRobin(teams []models.Team, opts FixtureOptions) ([]GeneratedFixture, error) {
+	n := len(teams)
+	if n < 6 || n%2 != 0 {
+		return nil, fmt.Errorf("%w: need an even number of at least 6 clubs, got %d", ErrInvalidFixtures, n)
+	}
+	seen := make(map[string]bool)
+	for _, t := range teams {
+		if t.ID == "" || seen[t.ID] {
+			return nil, fmt.Errorf("%w: club IDs must be unique and non-empty", ErrInvalidFixtures)
+		}
+		seen[t.ID] = true
+	}
+	if opts.DaysBetween <= 0 {
+		opts.DaysBetween = 7
+	}
+
+	rounds := circleRounds(n)
+	patterns := venuePatterns(rounds, n)
+	if longestVenueRun(patterns) > MaxConsecutiveVenue {
+		return nil, fmt.Errorf("no schedule for %d clubs keeps venue runs to %d", n, MaxConsecutiveVenue)
+	}
+
+	pairs, err := venuePairs(teams, opts.Pairs)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := assignSlots(teams, pairs, patterns, rand.New(rand.NewSource(opts.Seed)))
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []GeneratedFixture
+	for r, games := range rounds {
+		date := opts.StartDate.AddDate(0, 0, r*opts.DaysBetween)
+		for _, g := range games {
+			fixtures = append(fixtures, GeneratedFixture{
+				Matchday:   r + 1,
+				Date:       date,
+				HomeTeamID: slots[g[0]],
+				AwayTeamID: slots[g[1]],
+			})
+		}
+	}
+	return fixtures, nil
+}
+
+// circleRounds returns the 2(n-1) rounds of a mirrored double round-robin over slots
+// 0..n-1 as [home, away] slot pairs. Slot n-1 stays fixed while the others rotate;
+// starting the rotation at round 3 moves each slot's single break away from the halfway
+// point, where the mirror would otherwise turn it into three games at the same venue.
+func circleRounds(n int) [][][2]int {
+	first := make([][][2]int, 0, n-1)
+	for i := 0; i < n-1; i++ {
+		r := (i + 3) % (n - 1)
+		var games [][2]int
+		if r%2 == 0 {
+			games = append(games, [2]int{n - 1, r})
+		} else {
+			games = append(games, [2]int{r, n - 1})
+		}
+		for k := 1; k < n/2; k++ {
+			x, y := (r+k)%(n-1), (r-k+n-1)%(n-1)
+			if k%2 == 1 {
+				games = append(games, [2]int{x, y})
+			} else {
+				games = append(games, [2]int{y, x})
+			}
+		}
+		first = append(first, games)
+	}
+
+	rounds := append([][][2]int{}, first...)
+	for _, games := range first {
+		mirrored := make([][2]int, len(games))
+		for i, g := range games {
+			mirrored[i] = [2]int{g[1], g[0]}
+		}
+		rounds = append(rounds, mirrored)
+	}
+	return rounds
+}
+
+// venuePatterns returns, per slot, whether it is at home in each round
+func venuePatterns(rounds [][][2]int, n int) [][]bool {
+	patterns := make([][]bool, n)
+	for s := range patterns {
+		patterns[s] = make([]bool, len(rounds))
+	}
+	for r, games := range rounds {
+		for _, g := range games {
+			patterns[g[0]][r] = true
+		}
+	}
+	return patterns
+}
+
+func longestVenueRun(patterns [][]bool) int {
+	longest := 0
+	for _, p := range patterns {
+		run := 0
+		for i := range p {
+			if i > 0 && p[i] == p[i-1] {
+				run++
+			} else {
+				run = 1
+			}
+			if run > longest {
+				longest = run
+			}
+		}
+	}
+	return longest
+}
+
+// venuePairs returns the clubs that must not be at home on the same day: the explicit
+// pairs first, then clubs sharing a stadium, then clubs sharing a city. A club is in at
+// most one pair; with three or more clubs in one city the leftover ones are unpaired.
+func venuePairs(teams []models.Team, explicit [][2]string) ([][2]string, error) {
+	byID := make(map[string]bool)
+	for _, t := range teams {
+		byID[t.ID] = true
+	}
+
+	paired := make(map[string]bool)
+	var pairs [][2]string
+	add := func(a, b string) bool {
+		if a == b || paired[a] || paired[b] {
+			return false
+		}
+		paired[a], paired[b] = true, true
+		pairs = append(pairs, [2]string{a, b})
+		return true
+	}
+
+	for _, p := range explicit {
+		if !byID[p[0]] || !byID[p[1]] {
+			return nil, fmt.Errorf("%w: pair %s/%s is not in the league", ErrInvalidFixtures, p[0], p[1])
+		}
+		if !add(p[0], p[1]) {
+			return nil, fmt.Errorf("%w: a club can only be paired with one other club", ErrInvalidFixtures)
+		}
+	}
+
+	groupBy := func(key func(models.Team) string) {
+		groups := make(map[string][]string)
+		var order []string
+		for _, t := range teams {
+			k := strings.ToLower(strings.TrimSpace(key(t)))
+			if k == "" || paired[t.ID] {
+				continue
+			}
+			if _, ok := groups[k]; !ok {
+				order = append(order, k)
+			}
+			groups[k] = append(groups[k], t.ID)
+		}
+		for _, k := range order {
+			ids := groups[k]
+			for i := 0; i+1 < len(ids); i += 2 {
+				add(ids[i], ids[i+1])
+			}
+		}
+	}
+	groupBy(func(t models.Team) string { return t.Stadium })
+	groupBy(func(t models.Team) string { return t.City })
+	return pairs, nil
+}
+
+// assignSlots places the clubs in schedule slots, putting each pair in two slots with
+// opposite venue patterns and shuffling everyone else
+func assignSlots(teams []models.Team, pairs [][2]string, patterns [][]bool, rng *rand.Rand) ([]string, error) {
+	n := len(patterns)
+
+	var complements [][2]int
+	used := make([]bool, n)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n && !used[a]; b++ {
+			if !used[b] && oppositeVenues(patterns[a], patterns[b]) {
+				complements = append(complements, [2]int{a, b})
+				used[a], used[b] = true, true
+			}
+		}
+	}
+	if len(pairs) > len(complements) {
+		return nil, fmt.Errorf("%w: %d venue pairs but only %d can be kept apart", ErrInvalidFixtures, len(pairs), len(complements))
+	}
+	rng.Shuffle(len(complements), func(i, j int) { complements[i], complements[j] = complements[j], complements[i] })
+
+	slots := make([]string, n)
+	placed := make(map[string]bool)
+	taken := make([]bool, n)
+	for i, p := range pairs {
+		c := complements[i]
+		if rng.Intn(2) == 0 {
+			c[0], c[1] = c[1], c[0]
+		}
+		slots[c[0]], slots[c[1]] = p[0], p[1]
+		taken[c[0]], taken[c[1]] = true, true
+		placed[p[0]], placed[p[1]] = true, true
+	}
+
+	var free []int
+	for s := 0; s < n; s++ {
+		if !taken[s] {
+			free = append(free, s)
+		}
+	}
+	rng.Shuffle(len(free), func(i, j int) { free[i], free[j] = free[j], free[i] })
+	for _, t := range teams {
+		if placed[t.ID] {
+			continue
+		}
+		slots[free[0]] = t.ID
+		free = free[1:]
+	}
+	return slots, nil
+}
+
+func oppositeVenues(a, b []bool) bool {
+	for i := range a {
+		if a[i] == b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type GenerateFixturesRequest struct {
+	// TeamIDs defaults to the clubs of the season, or every team when the season has none
+	TeamIDs     []string    `json:"teamIds"`
+	StartDate   time.Time   `json:"startDate" binding:"required"`
+	DaysBetween int         `json:"daysBetween"`
+	Seed        int64       `json:"seed"`
+	Pairs       [][2]string `json:"pairs"`
+	// DryRun returns the fixtures without saving them, e.g. for what-if leagues
+	DryRun bool `json:"dryRun"`
+	// Replace deletes the season's existing fixtures first; refused once a match has started
+	Replace bool `json:"replace"`
+}
+
+type GenerateFixturesResult struct {
+	SeasonID string             `json:"seasonId"`
+	Saved    bool               `json:"saved"`
+	Fixtures []GeneratedFixture `json:"fixtures"`
+}
+
+// GenerateFixtures builds a double round-robin for a season and, unless it is a dry run,
+// stores it as the season's scheduled matches
+func (s *SeasonService) GenerateFixtures(season string, req GenerateFixturesRequest) (*GenerateFixturesResult, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.fixtureTeams(seasonID, req.TeamIDs)
+	if err != nil {
+		return nil, err
+	}
+	fixtures, err := GenerateRoundRobin(teams, FixtureOptions{
+		StartDate:   req.StartDate,
+		DaysBetween: req.DaysBetween,
+		Seed:        req.Seed,
+		Pairs:       req.Pairs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerateFixturesResult{SeasonID: seasonID, Fixtures: fixtures}
+	if req.DryRun {
+		return result, nil
+	}
+
+	existing, err := s.matchRepo.GetMatchesBySeason(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		if !req.Replace {
+			return nil, fmt.Errorf("%w: %s already has %d matches, set replace to overwrite them", ErrInvalidFixtures, seasonID, len(existing))
+		}
+		for _, m := range existing {
+			if m.Status != models.MatchScheduled {
+				return nil, fmt.Errorf("%w: %s has matches that have already started", ErrInvalidFixtures, seasonID)
+			}
+		}
+		if _, err := s.matchRepo.DeleteScheduledMatches(seasonID); err != nil {
+			return nil, err
+		}
+	}
+
+	matches := make([]models.Match, 0, len(fixtures))
+	for _, f := range fixtures {
+		matches = append(matches, models.Match{
+			ID:         models.SeasonScopedID(seasonID, fmt.Sprintf("M%d_%s_%s", f.Matchday, f.HomeTeamID, f.AwayTeamID)),
+			HomeTeamID: f.HomeTeamID,
+			AwayTeamID: f.AwayTeamID,
+			Date:       f.Date,
+			Matchday:   f.Matchday,
+			Status:     models.MatchScheduled,
+			SeasonID:   seasonID,
+		})
+	}
+	if err := s.matchRepo.CreateMatches(matches); err != nil {
+		return nil, err
+	}
+	result.Saved = true
+	return result, nil
+}
+
+// fixtureTeams loads the clubs to schedule: the requested IDs, else the season's clubs,
+// else every team
+func (s *SeasonService) fixtureTeams(seasonID string, ids []string) ([]models.Team, error) {
+	if len(ids) == 0 {
+		season, err := s.GetSeason(seasonID)
+		if err != nil {
+			return nil, err
+		}
+		ids = season.TeamIDs
+	}
+
+	all, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return all, nil
+	}
+
+	byID := make(map[string]models.Team)
+	for _, t := range all {
+		byID[t.ID] = t
+	}
+	teams := make([]models.Team, 0, len(ids))
+	for _, id := range ids {
+		t, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown team %s", ErrInvalidFixtures, id)
+		}
+		teams = append(teams, t)
+	}
+	return teams, nil
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+)
+
+func testTeams(n int) []models.Team {
+	teams := make([]models.Team, n)
+	for i := range teams {
+		teams[i] = models.Team{
+			ID:      fmt.Sprintf("t%02d", i),
+			Name:    fmt.Sprintf("Club %d", i),
+			Stadium: fmt.Sprintf("Ground %d", i),
+			City:    fmt.Sprintf("Town %d", i),
+		}
+	}
+	return teams
+}
+
+// checkFixtures checks the round-robin properties every fixture list must have and
+// returns whether each club is at home, per matchday
+func checkFixtures(t *testing.T, teams []models.Team, fixtures []GeneratedFixture, opts FixtureOptions) map[string][]bool {
+	t.Helper()
+	n := len(teams)
+	matchdays := 2 * (n - 1)
+	if len(fixtures) != n*(n-1) {
+		t.Fatalf("got %d fixtures, want %d", len(fixtures), n*(n-1))
+	}
+
+	played := make(map[[2]string]int)
+	home := make(map[string][]bool)
+	plays := make(map[string][]int)
+	for _, id := range teamIDs(teams) {
+		home[id] = make([]bool, matchdays)
+		plays[id] = make([]int, matchdays)
+	}
+	for _, f := range fixtures {
+		if f.Matchday < 1 || f.Matchday > matchdays {
+			t.Fatalf("matchday %d out of range", f.Matchday)
+		}
+		if want := opts.StartDate.AddDate(0, 0, (f.Matchday-1)*7); !f.Date.Equal(want) {
+			t.Errorf("matchday %d on %s, want %s", f.Matchday, f.Date, want)
+		}
+		if f.HomeTeamID == f.AwayTeamID {
+			t.Fatalf("%s plays itself", f.HomeTeamID)
+		}
+		played[[2]string{f.HomeTeamID, f.AwayTeamID}]++
+		home[f.HomeTeamID][f.Matchday-1] = true
+		plays[f.HomeTeamID][f.Matchday-1]++
+		plays[f.AwayTeamID][f.Matchday-1]++
+	}
+
+	for _, a := range teams {
+		for _, b := range teams {
+			if a.ID != b.ID && played[[2]string{a.ID, b.ID}] != 1 {
+				t.Errorf("%s v %s played %d times, want once", a.ID, b.ID, played[[2]string{a.ID, b.ID}])
+			}
+		}
+		for day, count := range plays[a.ID] {
+			if count != 1 {
+				t.Errorf("%s plays %d times on matchday %d", a.ID, count, day+1)
+			}
+		}
+		run := 1
+		for day := 1; day < matchdays; day++ {
+			if home[a.ID][day] == home[a.ID][day-1] {
+				run++
+			} else {
+				run = 1
+			}
+			if run > MaxConsecutiveVenue {
+				t.Errorf("%s has %d games at the same venue in a row up to matchday %d", a.ID, run, day+1)
+			}
+		}
+	}
+	return home
+}
+
+func checkApart(t *testing.T, home map[string][]bool, a, b string) {
+	t.Helper()
+	for day := range home[a] {
+		if home[a][day] && home[b][day] {
+			t.Errorf("%s and %s are both at home on matchday %d", a, b, day+1)
+		}
+	}
+}
+
+func TestGenerateRoundRobin(t *testing.T) {
+	opts := FixtureOptions{StartDate: time.Date(2026, time.August, 15, 14, 0, 0, 0, time.UTC), Seed: 7}
+	for n := 6; n <= 24; n += 2 {
+		t.Run(fmt.Sprintf("%d clubs", n), func(t *testing.T) {
+			teams := testTeams(n)
+			fixtures, err := GenerateRoundRobin(teams, opts)
+			if err != nil {
+				t.Fatalf("GenerateRoundRobin: %v", err)
+			}
+			checkFixtures(t, teams, fixtures, opts)
+
+			again, err := GenerateRoundRobin(teams, opts)
+			if err != nil || !reflect.DeepEqual(fixtures, again) {
+				t.Errorf("the same seed gave a different fixture list")
+			}
+		})
+	}
+}
+
+func TestGenerateRoundRobinVenuePairs(t *testing.T) {
+	opts := FixtureOptions{StartDate: time.Date(2026, time.August, 15, 14, 0, 0, 0, time.UTC)}
+	for n := 6; n <= 24; n += 2 {
+		t.Run(fmt.Sprintf("%d clubs", n), func(t *testing.T) {
+			teams := testTeams(n)
+			// t00 and t01 share a ground; t02, t03 and t04 are in one city, so only
+			// the first two of them are paired
+			teams[1].Stadium = teams[0].Stadium
+			teams[3].City, teams[4].City = teams[2].City, teams[2].City
+			opts := opts
+			if n >= 8 {
+				opts.Pairs = [][2]string{{"t05", "t07"}}
+			}
+
+			for seed := int64(0); seed < 5; seed++ {
+				opts.Seed = seed
+				fixtures, err := GenerateRoundRobin(teams, opts)
+				if err != nil {
+					t.Fatalf("GenerateRoundRobin: %v", err)
+				}
+				home := checkFixtures(t, teams, fixtures, opts)
+				checkApart(t, home, "t00", "t01")
+				checkApart(t, home, "t02", "t03")
+				if n >= 8 {
+					checkApart(t, home, "t05", "t07")
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRoundRobinInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []models.Team
+		pairs [][2]string
+	}{
+		{"too few clubs", testTeams(4), nil},
+		{"odd number of clubs", testTeams(7), nil},
+		{"duplicate club", append(testTeams(5), models.Team{ID: "t00"}), nil},
+		{"pair outside the league", testTeams(6), [][2]string{{"t00", "x"}}},
+		{"club in two pairs", testTeams(6), [][2]string{{"t00", "t01"}, {"t00", "t02"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerateRoundRobin(tt.teams, FixtureOptions{Pairs: tt.pairs})
+			if !errors.Is(err, ErrInvalidFixtures) {
+				t.Errorf("got %v, want ErrInvalidFixtures", err)
+			}
+		})
+	}
+}
@@ -2,9 +2,8 @@ package services
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"log"
@@ -13,85 +12,180 @@ import (
 	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type FootballService struct {
 	matchRepo *repositories.MatchRepository
 	teamRepo  *repositories.TeamRepository
+	eventRepo *repositories.DisciplineRepository
 }
 
 func NewFootballService() *FootballService {
 	return &FootballService{
 		matchRepo: repositories.NewMatchRepository(),
 		teamRepo:  repositories.NewTeamRepository(),
+		eventRepo: repositories.NewDisciplineRepository(),
 	}
 }
 
-func (s *FootballService) GetAllMatches() ([]models.Match, error) {
-	return s.matchRepo.GetAllMatches()
+// GetAllMatches returns every match of a season; an empty season means the active one
+func (s *FootballService) GetAllMatches(season string) ([]models.Match, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	return s.matchRepo.GetMatchesBySeason(seasonID)
 }
 
-// Helper for local JSON structure
+// MatchJSON is the flat match shape the frontend has used since matches were read
+// from results.json and next_matches.json
 type MatchJSON struct {
-	ID         string `json:"id"`
-	Matchday   int    `json:"matchday"`
-	Date       string `json:"date"`
-	Time       string `json:"time"`
-	HomeTeam   string `json:"homeTeam"`
-	AwayTeam   string `json:"awayTeam"`
-	HomeScore  int    `json:"homeScore"`
-	AwayScore  int    `json:"awayScore"`
-	HomeTeamID string `json:"homeTeamId"`
-	AwayTeamID string `json:"awayTeamId"`
+	ID            string `json:"id"`
+	Matchday      int    `json:"matchday"`
+	Date          string `json:"date"`
+	Time          string `json:"time"`
+	HomeTeam      string `json:"homeTeam"`
+	AwayTeam      string `json:"awayTeam"`
+	HomeScore     int    `json:"homeScore"`
+	AwayScore     int    `json:"awayScore"`
+	HalfTimeScore string `json:"halfTimeScore,omitempty"`
+	HomeTeamID    string `json:"homeTeamId"`
+	AwayTeamID    string `json:"awayTeamId"`
+}
+
+// fixtureJSON converts a match to MatchJSON with the date and time written the way
+// the fixture files wrote them ("Fri Aug/15 2025", "20.00")
+func fixtureJSON(m models.Match, teamNames map[string]string) MatchJSON {
+	return MatchJSON{
+		ID:            m.ID,
+		Matchday:      m.Matchday,
+		Date:          m.Date.Format("Mon Jan/2 2006"),
+		Time:          m.Date.Format("15.04"),
+		HomeTeam:      teamNames[m.HomeTeamID],
+		AwayTeam:      teamNames[m.AwayTeamID],
+		HomeScore:     m.HomeScore,
+		AwayScore:     m.AwayScore,
+		HalfTimeScore: m.HalfTime,
+		HomeTeamID:    m.HomeTeamID,
+		AwayTeamID:    m.AwayTeamID,
+	}
+}
+
+func (s *FootballService) teamNames() (map[string]string, error) {
+	teams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+// GetResultsJSON returns every finished match of a season in matchday order
+func (s *FootballService) GetResultsJSON(season string) ([]MatchJSON, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.matchRepo.GetFinishedMatches(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	return s.fixturesJSON(matches)
 }
 
-func (s *FootballService) GetStandings() ([]models.Standing, error) {
-	standings, err := s.matchRepo.GetStandings()
+// GetNextMatchesJSON returns every scheduled match of a season, earliest first
+func (s *FootballService) GetNextMatchesJSON(season string) ([]MatchJSON, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.matchRepo.GetUpcomingMatches(seasonID, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.fixturesJSON(matches)
+}
+
+func (s *FootballService) fixturesJSON(matches []models.Match) ([]MatchJSON, error) {
+	teamNames, err := s.teamNames()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MatchJSON, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, fixtureJSON(m, teamNames))
+	}
+	return result, nil
+}
+
+// GetStandings returns the table of a season; an empty season means the active one
+func (s *FootballService) GetStandings(season string) ([]models.Standing, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	standings, err := s.matchRepo.GetStandings(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Standings written by UpdateStandings only carry the team ID
+	allTeams, err := s.teamRepo.GetAllTeams()
 	if err != nil {
 		return nil, err
 	}
+	teamByID := make(map[string]models.Team)
+	for _, t := range allTeams {
+		teamByID[t.ID] = t
+	}
 
 	// Assign positions
+	maxPlayed := 0
 	for i := range standings {
 		standings[i].Position = i + 1
+		if standings[i].Team.ID == "" {
+			standings[i].Team = teamByID[standings[i].TeamID]
+		}
+		if standings[i].Played > maxPlayed {
+			maxPlayed = standings[i].Played
+		}
 	}
 
-	// Try to load next matches for next opponent info
-	nextBytes, err := os.ReadFile("../next_matches.json")
-	if err == nil {
-		var nextMatches []MatchJSON
-		if err := json.Unmarshal(nextBytes, &nextMatches); err == nil {
-			// Map: Team Name -> Logo URL
-			logoMap := make(map[string]string)
-			for _, s := range standings {
-				logoMap[s.Team.Name] = s.Team.LogoURL
-				logoMap[normalizeTeamName(s.Team.Name)] = s.Team.LogoURL
-			}
+	// Postponed matches leave clubs with games in hand
+	for i := range standings {
+		standings[i].GamesInHand = maxPlayed - standings[i].Played
+	}
 
-			nextOpponentMap := make(map[string]string)
-			for _, m := range nextMatches {
-				home := normalizeTeamName(m.HomeTeam)
-				away := normalizeTeamName(m.AwayTeam)
-
-				if _, ok := nextOpponentMap[home]; !ok {
-					nextOpponentMap[home] = m.AwayTeam
-				}
-				if _, ok := nextOpponentMap[away]; !ok {
-					nextOpponentMap[away] = m.HomeTeam
-				}
-			}
+	// Next opponent: each team's earliest scheduled match
+	nextMatches, err := s.matchRepo.GetUpcomingMatches(seasonID, 0)
+	if err != nil {
+		return nil, err
+	}
 
-			for i := range standings {
-				normName := normalizeTeamName(standings[i].Team.Name)
-				if opp, ok := nextOpponentMap[normName]; ok {
-					standings[i].NextOpponent = opp
-					if logo, hasLogo := logoMap[normalizeTeamName(opp)]; hasLogo {
-						standings[i].NextOpponentLogo = logo
-					} else if logo, hasLogo := logoMap[opp]; hasLogo {
-						standings[i].NextOpponentLogo = logo
-					}
-				}
-			}
+	type opponent struct{ name, logo string }
+	teams := make(map[string]opponent)
+	for _, st := range standings {
+		teams[st.TeamID] = opponent{st.Team.Name, st.Team.LogoURL}
+	}
+
+	nextOpponentMap := make(map[string]opponent)
+	for _, m := range nextMatches {
+		if _, ok := nextOpponentMap[m.HomeTeamID]; !ok {
+			nextOpponentMap[m.HomeTeamID] = teams[m.AwayTeamID]
+		}
+		if _, ok := nextOpponentMap[m.AwayTeamID]; !ok {
+			nextOpponentMap[m.AwayTeamID] = teams[m.HomeTeamID]
+		}
+	}
+
+	for i := range standings {
+		if opp, ok := nextOpponentMap[standings[i].TeamID]; ok {
+			standings[i].NextOpponent = opp.name
+			standings[i].NextOpponentLogo = opp.logo
 		}
 	}
 
@@ -105,21 +199,19 @@ func (s *FootballService) GetStandings() ([]models.Standing, error) {
 	return standings, nil
 }
 
-func normalizeTeamName(name string) string {
-	name = strings.TrimSpace(name)
-	if strings.HasSuffix(name, " FC") {
-		return strings.TrimSpace(name[:len(name)-3])
-	}
-	if strings.HasSuffix(name, " AFC") {
-		return strings.TrimSpace(name[:len(name)-4])
-	}
-	return name
-}
-
 func (s *FootballService) CreateMatch(match *models.Match) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if match.SeasonID == "" {
+		seasonID, err := ResolveSeasonID("")
+		if err != nil {
+			return err
+		}
+		match.SeasonID = seasonID
+	}
+	match.SeasonID = models.NormalizeSeasonID(match.SeasonID)
+
 	// 1. Create the Match
 	if err := s.matchRepo.CreateMatch(match); err != nil {
 		return err
@@ -141,41 +233,114 @@ func (s *FootballService) GetTeamByID(id string) (*models.Team, error) {
 	return s.teamRepo.GetTeamByID(id)
 }
 
-func (s *FootballService) GetMatchByID(id string) (*models.Match, error) {
-	return s.matchRepo.GetMatchByID(id)
-}
-
-func (s *FootballService) UpdateMatchStatus(id string, status models.MatchStatus) error {
-	match, err := s.matchRepo.GetMatchByID(id)
+// UpdateTeamAliases replaces a team's aliases and external IDs. An alias that already
+// resolves to a different team is rejected, since it would make both unresolvable.
+func (s *FootballService) UpdateTeamAliases(teamID string, aliases []string, externalIDs map[string]string) (*models.Team, error) {
+	if _, err := s.teamRepo.GetTeamByID(teamID); err != nil {
+		return nil, err
+	}
+	resolver, err := LoadTeamResolver(s.teamRepo)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	cleaned := []string{}
+	seen := make(map[string]bool)
+	for _, alias := range aliases {
+		key := NormalizeTeamName(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		if team, ok := resolver.Resolve(alias); ok && team.ID != teamID {
+			return nil, fmt.Errorf("alias %q already refers to %s", alias, team.Name)
+		}
+		seen[key] = true
+		cleaned = append(cleaned, strings.TrimSpace(alias))
 	}
 
-	match.Status = status
-	return s.matchRepo.UpdateMatch(match)
+	if err := s.teamRepo.UpdateTeamAliases(teamID, cleaned, externalIDs); err != nil {
+		return nil, err
+	}
+	return s.teamRepo.GetTeamByID(teamID)
+}
+
+func (s *FootballService) GetMatchByID(id string) (*models.Match, error) {
+	return s.matchRepo.GetMatchByID(id)
 }
 
 func (s *FootballService) GetTeamSquad(teamID string) ([]models.Player, error) {
 	return s.matchRepo.GetTeamSquad(teamID)
 }
 
-func (s *FootballService) GetAllPlayers() ([]models.Player, error) {
-	return s.matchRepo.GetAllPlayers()
+// GetAllPlayers returns every player with Statistics set to their numbers for the
+// season; the career totals move to CareerStatistics
+func (s *FootballService) GetAllPlayers(season string) ([]models.Player, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	players, err := s.matchRepo.GetAllPlayers()
+	if err != nil {
+		return nil, err
+	}
+	seasonStats, err := s.matchRepo.GetPlayerSeasonStats(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	byPlayer := make(map[string]models.PlayerStats)
+	for _, st := range seasonStats {
+		byPlayer[st.PlayerID] = st.PlayerStats
+	}
+	for i := range players {
+		withSeasonStats(&players[i], seasonID, byPlayer[players[i].ID])
+	}
+	return players, nil
 }
 
-func (s *FootballService) GetPlayerByID(playerID string) (*models.Player, error) {
-	return s.matchRepo.GetPlayerByID(playerID)
+// GetPlayerByID returns a player with Statistics set to their numbers for the season
+func (s *FootballService) GetPlayerByID(season, playerID string) (*models.Player, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	player, err := s.matchRepo.GetPlayerByID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	var stats models.PlayerStats
+	st, err := s.matchRepo.GetPlayerSeasonStatsByID(seasonID, playerID)
+	if err == nil {
+		stats = st.PlayerStats
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	withSeasonStats(player, seasonID, stats)
+	return player, nil
+}
+
+func withSeasonStats(player *models.Player, seasonID string, stats models.PlayerStats) {
+	career := player.Statistics
+	player.CareerStatistics = &career
+	player.Statistics = stats
+	player.SeasonID = seasonID
 }
 
 type TeamMatchesResponse struct {
 	Team          models.Team `json:"team"`
 	RecentMatches []MatchJSON `json:"recentMatches"` // Last 5 results
 	NextMatch     *MatchJSON  `json:"nextMatch"`     // Immediate next match
-	Upcoming      []MatchJSON `json:"upcoming"`      // Next 5 matches (excluding immediate next if desired, or just next 5)
+	Upcoming      []MatchJSON `json:"upcoming"`      // The 5 matches after NextMatch
 	Form          []string    `json:"form"`          // W, D, L for recent matches
 }
 
-func (s *FootballService) GetTeamMatches(teamID string) (*TeamMatchesResponse, error) {
+// GetTeamMatches returns a team's results, form and next matches in a season; an empty
+// season is the active one
+func (s *FootballService) GetTeamMatches(teamID, season string) (*TeamMatchesResponse, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. Get Team
 	team, err := s.teamRepo.GetTeamByID(teamID)
 	if err != nil {
@@ -189,92 +354,51 @@ func (s *FootballService) GetTeamMatches(teamID string) (*TeamMatchesResponse, e
 		Form:          []string{},
 	}
 
-	targetName := normalizeTeamName(team.Name)
-	log.Printf("Getting matches for team: %s (Normalized: %s)", team.Name, targetName)
-
-	// 2. Get Past Matches (Results)
-	resultsBytes, err := os.ReadFile("../results.json")
+	matches, err := s.matchRepo.GetMatchesByTeamID(seasonID, teamID)
 	if err != nil {
-		log.Printf("Error reading results.json: %v", err)
-	} else {
-		var results []MatchJSON
-		if err := json.Unmarshal(resultsBytes, &results); err == nil {
-			log.Printf("Total results in file: %d", len(results))
-			// Filter for this team
-			var teamResults []MatchJSON
-			for _, m := range results {
-				if normalizeTeamName(m.HomeTeam) == targetName || normalizeTeamName(m.AwayTeam) == targetName {
-					teamResults = append(teamResults, m)
-				}
-			}
-			log.Printf("Found %d results for team %s", len(teamResults), targetName)
-
-			// Sort descending (assuming they are chronological in file, we reverse)
-			// Actually file is chronological 1..N. We want latest first.
-			// Let's just traverse backwards from the end of teamResults
-			count := 0
-			for i := len(teamResults) - 1; i >= 0 && count < 5; i-- {
-				m := teamResults[i]
-				response.RecentMatches = append(response.RecentMatches, m)
-
-				// Calculate Form
-				var result string
-				isHome := normalizeTeamName(m.HomeTeam) == targetName
-
-				if m.HomeScore == m.AwayScore {
-					result = "D"
-				} else if m.HomeScore > m.AwayScore {
-					if isHome {
-						result = "W"
-					} else {
-						result = "L"
-					}
-				} else { // Home < Away
-					if isHome {
-						result = "L"
-					} else {
-						result = "W"
-					}
-				}
-				response.Form = append(response.Form, result)
-				count++
-			}
+		return nil, err
+	}
+	teamNames, err := s.teamNames()
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Past matches, latest first, with the form they give
+	var results, upcoming []models.Match
+	for _, m := range matches {
+		switch m.Status {
+		case models.MatchFinished:
+			results = append(results, m)
+		case models.MatchScheduled:
+			upcoming = append(upcoming, m)
 		}
 	}
 
-	// 3. Get Upcoming Matches
-	nextBytes, err := os.ReadFile("../next_matches.json")
-	if err == nil {
-		var nextMatches []MatchJSON
-		if err := json.Unmarshal(nextBytes, &nextMatches); err == nil {
-			// Filter
-			var teamNext []MatchJSON
-			for _, m := range nextMatches {
-				if normalizeTeamName(m.HomeTeam) == targetName || normalizeTeamName(m.AwayTeam) == targetName {
-					teamNext = append(teamNext, m)
-				}
-			}
+	for i := len(results) - 1; i >= 0 && len(response.RecentMatches) < 5; i-- {
+		m := results[i]
+		response.RecentMatches = append(response.RecentMatches, fixtureJSON(m, teamNames))
 
-			// Looking for next match and subsequent 5
-			// Accessing linearly assuming sorted by date
-			if len(teamNext) > 0 {
-				response.NextMatch = &teamNext[0]
-
-				// Get next 5 (including or excluding next match? User said "next match then next 5 matches")
-				// Let's get next 5 *after* the immediate one if possible, or just next 5 total
-				// Interpret "next match implies the very first one". "next 5 matches" often excludes it in UI if shown separately.
-				// Let's provide the slice of next 5 starting from index 1 if exists.
-
-				startIndex := 1
-				endIndex := startIndex + 5
-				if endIndex > len(teamNext) {
-					endIndex = len(teamNext)
-				}
-
-				if startIndex < len(teamNext) {
-					response.Upcoming = teamNext[startIndex:endIndex]
-				}
+		isHome := m.HomeTeamID == teamID
+		switch {
+		case m.HomeScore == m.AwayScore:
+			response.Form = append(response.Form, "D")
+		case (m.HomeScore > m.AwayScore) == isHome:
+			response.Form = append(response.Form, "W")
+		default:
+			response.Form = append(response.Form, "L")
+		}
+	}
+
+	// 3. The immediate next match, then the 5 after it
+	if len(upcoming) > 0 {
+		next := fixtureJSON(upcoming[0], teamNames)
+		response.NextMatch = &next
+
+		for _, m := range upcoming[1:] {
+			if len(response.Upcoming) == 5 {
+				break
 			}
+			response.Upcoming = append(response.Upcoming, fixtureJSON(m, teamNames))
 		}
 	}
 
@@ -298,14 +422,19 @@ func (s *FootballService) StartMatch(matchID string) error {
 	if err != nil {
 		return err
 	}
-	if match.Matchday != activeMatchday {
+	// A rescheduled match from an earlier matchday can be played in between
+	if match.Matchday != activeMatchday && !(match.Rescheduled() && match.Matchday < activeMatchday) {
 		return fmt.Errorf("can only start matches for current Matchday %d (this match is Matchday %d)", activeMatchday, match.Matchday)
 	}
 
-	// Update status to LIVE
-	if err := s.matchRepo.UpdateMatchStatus(matchID, models.MatchLive); err != nil {
+	// Update status to LIVE, unless the kickoff scheduler got there first
+	started, err := s.matchRepo.StartMatchIfScheduled(matchID, time.Now())
+	if err != nil {
 		return err
 	}
+	if !started {
+		return fmt.Errorf("match %s has already been started", matchID)
+	}
 
 	// Start background simulation
 	SimulateMatch(matchID)
@@ -313,58 +442,39 @@ func (s *FootballService) StartMatch(matchID string) error {
 	return nil
 }
 
-// GetActiveMatchday returns the first matchday that has SCHEDULED or LIVE matches.
-// If all are finished, returns the last matchday + 1 (or just max matchday).
+// GetActiveMatchday returns the first matchday that still has SCHEDULED or LIVE matches.
+// Postponed, abandoned and cancelled matches don't hold a matchday open, and neither do
+// matches that have been rescheduled: those are played whenever their new date comes.
+// If all are finished, returns the last matchday.
 func (s *FootballService) GetActiveMatchday() (int, error) {
-	matches, err := s.matchRepo.GetAllMatches()
+	seasonID, err := ResolveSeasonID("")
 	if err != nil {
 		return 0, err
 	}
-
-	// Find min matchday that is not all FINISHED
-	// Actually simpler: Find first matchday where status != FINISHED
-	// Matches are not guaranteed sorted by matchday in DB return, so let's check properly.
-
-	// Group by matchday
-	statusByDay := make(map[int][]models.MatchStatus)
-	minDay := 100
-	maxDay := 0
-
-	for _, m := range matches {
-		statusByDay[m.Matchday] = append(statusByDay[m.Matchday], m.Status)
-		if m.Matchday < minDay {
-			minDay = m.Matchday
-		}
-		if m.Matchday > maxDay {
-			maxDay = m.Matchday
-		}
+	matches, err := s.matchRepo.GetMatchesBySeason(seasonID)
+	if err != nil {
+		return 0, err
 	}
-
 	if len(matches) == 0 {
 		return 1, nil
 	}
 
-	// Check from minDay upwards
-	for day := minDay; day <= maxDay; day++ {
-		statuses, exists := statusByDay[day]
-		if !exists {
-			continue
-		}
-
-		allFinished := true
-		for _, st := range statuses {
-			if st != models.MatchFinished {
-				allFinished = false
-				break
-			}
+	// Matches are not guaranteed sorted by matchday in DB return, so check them all
+	active, maxDay := 0, 0
+	for _, m := range matches {
+		if m.Matchday > maxDay {
+			maxDay = m.Matchday
 		}
-
-		if !allFinished {
-			return day, nil
+		pending := m.Status == models.MatchScheduled || m.Status == models.MatchLive
+		if pending && !m.Rescheduled() && (active == 0 || m.Matchday < active) {
+			active = m.Matchday
 		}
 	}
 
-	return maxDay, nil // All finished
+	if active == 0 {
+		return maxDay, nil // All played or waiting for a new date
+	}
+	return active, nil
 }
 
 // FinishMatch transitions a match from LIVE to FINISHED and recalculates stats
@@ -404,6 +514,11 @@ func (s *FootballService) FinishMatch(matchID string) error {
 		log.Printf("Error updating player stats: %v", err)
 	}
 
+	// Serve and issue suspensions
+	if err := ProcessMatchDiscipline(context.Background(), matchID); err != nil {
+		log.Printf("Error processing discipline: %v", err)
+	}
+
 	log.Printf("[FinishMatch] Match %s finished: %d-%d. Standings and player stats updated.", matchID, homeScore, awayScore)
 	return nil
 }
@@ -449,15 +564,24 @@ func (s *FootballService) recalculateAfterEventChange(matchID string) error {
 		return err
 	}
 
-	// Recalculate all standings from scratch
-	return RecalculateAllStandings(ctx)
+	match, err := s.matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return err
+	}
+
+	// Recalculate the season's standings from scratch
+	return RecalculateSeasonStandings(ctx, match.SeasonID)
 }
 
 // --- Matchday ---
 
-// GetMatchesByMatchday returns all matches for a given matchday
-func (s *FootballService) GetMatchesByMatchday(matchday int) ([]models.Match, error) {
-	return s.matchRepo.GetMatchesByMatchday(matchday)
+// GetMatchesByMatchday returns all matches for a given matchday of a season
+func (s *FootballService) GetMatchesByMatchday(season string, matchday int) ([]models.Match, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	return s.matchRepo.GetMatchesByMatchday(seasonID, matchday)
 }
 
 // --- Player CRUD ---
@@ -481,10 +605,14 @@ func (s *FootballService) DeletePlayer(playerID string) error {
 	return s.matchRepo.DeletePlayer(playerID)
 }
 
-// GetLatestResults fetches finished matches from DB and formats them for frontend
-func (s *FootballService) GetLatestResults() ([]MatchJSON, error) {
+// GetLatestResults fetches a season's finished matches from DB and formats them for frontend
+func (s *FootballService) GetLatestResults(season string) ([]MatchJSON, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
 	// Get last 2 finished matches
-	matches, err := s.matchRepo.GetLatestResultMatches(2)
+	matches, err := s.matchRepo.GetLatestResultMatches(seasonID, 2)
 	if err != nil {
 		return nil, err
 	}
@@ -518,7 +646,11 @@ func (s *FootballService) GetLatestResults() ([]MatchJSON, error) {
 
 // GetUpcomingFixtures fetches scheduled matches from DB
 func (s *FootballService) GetUpcomingFixtures() ([]MatchJSON, error) {
-	matches, err := s.matchRepo.GetUpcomingMatches(3)
+	seasonID, err := ResolveSeasonID("")
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.matchRepo.GetUpcomingMatches(seasonID, 3)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// LineupSize is the number of starters a team names for a match
+	LineupSize = 11
+	// FullTime is the minute a match ends when counting minutes played; stoppage time is not counted
+	FullTime = 90
+)
+
+// SetLineup validates and stores a team's starting lineup for a scheduled match.
+// Every player must belong to the team and must not be serving a suspension.
+func (s *FootballService) SetLineup(matchID, teamID string, playerIDs []string) error {
+	match, err := s.matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return err
+	}
+	if match.Status != models.MatchScheduled {
+		return fmt.Errorf("lineups can only be set for SCHEDULED matches (current: %s)", match.Status)
+	}
+	if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+		return fmt.Errorf("team %s is not playing in this match", teamID)
+	}
+	if len(playerIDs) != LineupSize {
+		return fmt.Errorf("a lineup needs exactly %d players, got %d", LineupSize, len(playerIDs))
+	}
+
+	squad, err := s.matchRepo.GetTeamSquad(teamID)
+	if err != nil {
+		return err
+	}
+	inSquad := make(map[string]models.Player)
+	for _, p := range squad {
+		inSquad[p.ID] = p
+	}
+	suspended, err := SuspendedPlayerIDs(match.SeasonID, teamID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range playerIDs {
+		player, ok := inSquad[id]
+		if !ok {
+			return fmt.Errorf("player %s is not in the squad of team %s", id, teamID)
+		}
+		if seen[id] {
+			return fmt.Errorf("player %s is listed twice", player.Name)
+		}
+		seen[id] = true
+		if suspended[id] {
+			return fmt.Errorf("player %s is suspended", player.Name)
+		}
+	}
+
+	return s.matchRepo.UpdateMatchLineup(matchID, teamID == match.HomeTeamID, playerIDs)
+}
+
+// RecordSubstitution brings playerOnID on for playerOffID in a live or finished match.
+// The team must have a lineup, and playerOffID must be on the pitch at the time.
+func (s *FootballService) RecordSubstitution(matchID, playerOnID, playerOffID string, minute int) (*models.MatchEvent, error) {
+	match, err := s.matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.Status != models.MatchLive && match.Status != models.MatchFinished {
+		return nil, fmt.Errorf("substitutions can only be recorded for LIVE or FINISHED matches (current: %s)", match.Status)
+	}
+	if minute < 0 {
+		return nil, fmt.Errorf("invalid minute: %d", minute)
+	}
+	on, err := s.matchRepo.GetPlayerByID(playerOnID)
+	if err != nil {
+		return nil, err
+	}
+	off, err := s.matchRepo.GetPlayerByID(playerOffID)
+	if err != nil {
+		return nil, err
+	}
+	if on.TeamID != off.TeamID {
+		return nil, fmt.Errorf("%s and %s play for different teams", on.Name, off.Name)
+	}
+	lineup := match.HomeLineup
+	switch on.TeamID {
+	case match.HomeTeamID:
+	case match.AwayTeamID:
+		lineup = match.AwayLineup
+	default:
+		return nil, fmt.Errorf("player %s does not play for either team", on.Name)
+	}
+	if len(lineup) == 0 {
+		return nil, fmt.Errorf("team %s has no lineup for this match", on.TeamID)
+	}
+
+	events, err := s.eventRepo.GetMatchEvents(matchID)
+	if err != nil {
+		return nil, err
+	}
+	onPitch := make(map[string]bool)
+	played := make(map[string]bool)
+	for _, id := range lineup {
+		onPitch[id] = true
+		played[id] = true
+	}
+	for _, e := range events {
+		if e.Type == models.Substitution && e.Minute <= minute {
+			onPitch[e.PlayerID] = true
+			onPitch[e.ReplacedPlayerID] = false
+		}
+		if e.Type == models.Substitution {
+			played[e.PlayerID] = true
+		}
+	}
+	if !onPitch[playerOffID] {
+		return nil, fmt.Errorf("player %s is not on the pitch", off.Name)
+	}
+	if played[playerOnID] {
+		return nil, fmt.Errorf("player %s has already played in this match", on.Name)
+	}
+
+	event := &models.MatchEvent{
+		ID:               primitive.NewObjectID().Hex(),
+		MatchID:          matchID,
+		PlayerID:         playerOnID,
+		PlayerName:       on.Name,
+		TeamID:           on.TeamID,
+		SeasonID:         match.SeasonID,
+		Matchday:         match.Matchday,
+		Type:             models.Substitution,
+		Minute:           minute,
+		ReplacedPlayerID: playerOffID,
+	}
+	if err := s.eventRepo.CreateMatchEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// minutesPlayed is how long a player was on the pitch for teamID in m, from the team's
+// lineup and substitutions, or nil if the team recorded no lineup. off is the minute the
+// player's match ended at the latest, FullTime or when they were sent off.
+func minutesPlayed(m models.Match, teamID, playerID string, subs []models.MatchEvent, off int) *int {
+	lineup := m.HomeLineup
+	if teamID == m.AwayTeamID {
+		lineup = m.AwayLineup
+	}
+	if len(lineup) == 0 {
+		return nil
+	}
+
+	start := -1
+	for _, id := range lineup {
+		if id == playerID {
+			start = 0
+		}
+	}
+	for _, e := range subs {
+		if e.PlayerID == playerID && start < 0 {
+			start = e.Minute
+		}
+		if e.ReplacedPlayerID == playerID && e.Minute < off {
+			off = e.Minute
+		}
+	}
+
+	minutes := 0
+	if start >= 0 && off > start {
+		minutes = min(off, FullTime) - min(start, FullTime)
+	}
+	return &minutes
+}
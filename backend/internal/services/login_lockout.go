@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrAccountLocked = errors.New("too many failed logins")
+
+// loginFailureMemory is how long a failed login counts towards a lockout
+const loginFailureMemory = 24 * time.Hour
+
+// AccountLockedError is returned by Login while an address is locked out
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrAccountLocked.Error() + ", try again later"
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// RetryAfter is how long until the lockout ends
+func (e *AccountLockedError) RetryAfter() time.Duration {
+	return time.Until(e.Until)
+}
+
+// loginAttemptStore keeps the failed logins; LoginAttemptRepository in production
+type loginAttemptStore interface {
+	GetLoginAttempt(key string) (*models.LoginAttempt, error)
+	RecordFailure(key string, resetAfter time.Duration) (*models.LoginAttempt, error)
+	LockUntil(key string, until time.Time) error
+	ClearLoginAttempts(key string) error
+}
+
+func lockoutKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLockout fails with an AccountLockedError while email is locked out. If the
+// attempts can't be read, the login goes ahead.
+func (s *AuthService) checkLockout(email string) error {
+	attempt, err := s.loginAttempts.GetLoginAttempt(lockoutKey(email))
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("[Auth] Failed to read the login attempts of %s: %v", email, err)
+		}
+		return nil
+	}
+	if attempt.LockedUntil != nil && time.Now().Before(*attempt.LockedUntil) {
+		return &AccountLockedError{Until: *attempt.LockedUntil}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login. Past the threshold each failure locks the
+// address out for twice as long as the last, up to the maximum.
+func (s *AuthService) recordLoginFailure(email string) {
+	key := lockoutKey(email)
+	attempt, err := s.loginAttempts.RecordFailure(key, loginFailureMemory)
+	if err != nil {
+		log.Printf("[Auth] Failed to record a failed login of %s: %v", email, err)
+		return
+	}
+
+	cfg := config.LoadConfig()
+	over := attempt.Failures - cfg.LoginLockoutThreshold
+	if cfg.LoginLockoutThreshold <= 0 || over < 0 {
+		return
+	}
+	lockout := time.Duration(cfg.LoginLockoutBase) * time.Second
+	longest := time.Duration(cfg.LoginLockoutMax) * time.Minute
+	for i := 0; i < over && lockout < longest; i++ {
+		lockout *= 2
+	}
+	if lockout > longest {
+		lockout = longest
+	}
+
+	until := time.Now().Add(lockout)
+	if err := s.loginAttempts.LockUntil(key, until); err != nil {
+		log.Printf("[Auth] Failed to lock out %s: %v", email, err)
+		return
+	}
+	log.Printf("[Auth] %s locked out for %s after %d failed logins", email, lockout, attempt.Failures)
+}
+
+func (s *AuthService) clearLoginFailures(email string) {
+	if err := s.loginAttempts.ClearLoginAttempts(lockoutKey(email)); err != nil {
+		log.Printf("[Auth] Failed to clear the login attempts of %s: %v", email, err)
+	}
+}
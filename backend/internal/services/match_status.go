@@ -0,0 +1,88 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+)
+
+var ErrInvalidStatusChange = errors.New("invalid match status change")
+
+// Which statuses a match may be moved to by hand. LIVE and FINISHED are reached
+// through StartMatch and FinishMatch, and SCHEDULED again through RescheduleMatch.
+var manualTransitions = map[models.MatchStatus][]models.MatchStatus{
+	models.MatchScheduled: {models.MatchPostponed, models.MatchCancelled},
+	models.MatchLive:      {models.MatchAbandoned},
+	models.MatchPostponed: {models.MatchCancelled},
+	models.MatchAbandoned: {models.MatchCancelled},
+}
+
+// UpdateMatchStatus postpones, abandons or cancels a match. Abandoning a live match
+// stops its simulation; its score stays as it was when play stopped until it is rescheduled.
+func (s *FootballService) UpdateMatchStatus(id string, status models.MatchStatus, reason string) error {
+	if !status.Valid() {
+		return fmt.Errorf("%w: unknown status %s", ErrInvalidStatusChange, status)
+	}
+	match, err := s.matchRepo.GetMatchByID(id)
+	if err != nil {
+		return err
+	}
+
+	allowed := false
+	for _, to := range manualTransitions[match.Status] {
+		if to == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: a %s match cannot be set to %s", ErrInvalidStatusChange, match.Status, status)
+	}
+
+	if status == models.MatchAbandoned {
+		StopSimulation(id)
+	}
+	return s.matchRepo.SetMatchStatus(id, status, reason)
+}
+
+// RescheduleMatch gives a postponed, abandoned or scheduled match a new date. The match
+// keeps its matchday and remembers the date it was first scheduled for. An abandoned
+// match is replayed from scratch, so its goals, cards, shots and substitutions are
+// thrown away, along with any suspensions its cards triggered.
+func (s *FootballService) RescheduleMatch(id string, date time.Time) (*models.Match, error) {
+	if date.IsZero() {
+		return nil, fmt.Errorf("%w: a new date is required", ErrInvalidStatusChange)
+	}
+	match, err := s.matchRepo.GetMatchByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch match.Status {
+	case models.MatchScheduled, models.MatchPostponed:
+	case models.MatchAbandoned:
+		if err := s.matchRepo.DeleteMatchGoalEvents(id); err != nil {
+			return nil, err
+		}
+		discipline := repositories.NewDisciplineRepository()
+		if err := discipline.DeleteMatchEvents(id); err != nil {
+			return nil, err
+		}
+		if err := discipline.DeleteMatchSuspensions(id); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: a %s match cannot be rescheduled", ErrInvalidStatusChange, match.Status)
+	}
+
+	originalDate := match.Date
+	if match.OriginalDate != nil {
+		originalDate = *match.OriginalDate
+	}
+	if err := s.matchRepo.RescheduleMatch(id, date, originalDate); err != nil {
+		return nil, err
+	}
+	return s.matchRepo.GetMatchByID(id)
+}
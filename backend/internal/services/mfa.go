@@ -0,0 +1,318 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/totp"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrMFARequired       = errors.New("two-factor authentication required")
+	ErrInvalidMFAToken   = errors.New("invalid or expired sign-in, sign in again")
+	ErrInvalidMFACode    = errors.New("invalid two-factor code")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication is not set up")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already on")
+	ErrMFAMandatory      = errors.New("two-factor authentication is mandatory for your role")
+)
+
+const (
+	// MFAIssuer names the app in authenticator apps
+	MFAIssuer = "EPL"
+	// RecoveryCodeCount is how many recovery codes confirming enrolment hands out
+	RecoveryCodeCount = 10
+	// MFAChallengeAttempts is how many wrong codes an MFA token takes before the user
+	// has to sign in again
+	MFAChallengeAttempts = 5
+)
+
+// mfaCodeStore spends TOTP steps and recovery codes so that each works once;
+// UserRepository in production
+type mfaCodeStore interface {
+	UseMFAStep(id primitive.ObjectID, step int64) error
+	UseRecoveryCode(id primitive.ObjectID, codeHash string) error
+}
+
+// MFAChallengeError is returned when a sign-in checked out but still needs a TOTP code.
+// Token is exchanged for a session with VerifyMFA, or, if Enroll is set because the
+// user's role requires two-factor authentication and they haven't set it up, with
+// EnrollMFA and ConfirmMFAEnrolment.
+type MFAChallengeError struct {
+	Token     string
+	ExpiresIn int // Seconds the token is valid
+	Enroll    bool
+}
+
+func (e *MFAChallengeError) Error() string {
+	return ErrMFARequired.Error()
+}
+
+func (e *MFAChallengeError) Unwrap() error {
+	return ErrMFARequired
+}
+
+// MFAEnrolment is what the user adds to their authenticator app
+type MFAEnrolment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth:// URI, for a QR code
+}
+
+// mfaRequired reports whether role must use two-factor authentication. Unless
+// MFA_REQUIRED_ROLES lists the roles, every role that can manage users or matches must.
+func mfaRequired(role string) bool {
+	roles := strings.TrimSpace(config.LoadConfig().MFARequiredRoles)
+	if roles == "" {
+		return models.RoleHas(role, models.PermManageUsers) || models.RoleHas(role, models.PermManageMatches)
+	}
+	for _, r := range strings.Split(roles, ",") {
+		if strings.TrimSpace(r) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// signIn starts a session for a user who has proven who they are, unless they need a
+// second factor, in which case it returns an MFAChallengeError
+func (s *AuthService) signIn(user *models.User, client Client) (*TokenPair, error) {
+	if !user.MFAEnabled() && !mfaRequired(user.Role) {
+		return s.startSession(user, client)
+	}
+	token, err := utils.GenerateMFAToken(user.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return nil, &MFAChallengeError{
+		Token:     token,
+		ExpiresIn: int(utils.MFATokenTTL.Seconds()),
+		Enroll:    !user.MFAEnabled(),
+	}
+}
+
+// VerifyMFA completes a sign-in with a TOTP or recovery code. Wrong codes count towards
+// the login lockout of the user's address, and after MFAChallengeAttempts of them the
+// token stops working.
+func (s *AuthService) VerifyMFA(mfaToken, code string, client Client) (*TokenPair, *models.User, error) {
+	user, err := s.pendingMFAUser(mfaToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.MFAEnabled() {
+		return nil, nil, ErrMFANotEnrolled
+	}
+	if err := s.checkLockout(user.Email); err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkMFACode(user, code); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			s.recordMFAFailure(mfaToken, user.Email)
+		}
+		return nil, nil, err
+	}
+	s.clearLoginFailures(user.Email)
+
+	tokens, err := s.startSession(user, client)
+	return tokens, user, err
+}
+
+// EnrollMFA gives the user a new TOTP secret. Two-factor authentication is on once
+// ConfirmMFA checks a code from it; until then the user can enrol again.
+func (s *AuthService) EnrollMFA(userID string) (*MFAEnrolment, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetMFA(user.ID, &models.MFA{Secret: secret}); err != nil {
+		return nil, err
+	}
+	return &MFAEnrolment{Secret: secret, URI: totp.URI(MFAIssuer, user.Email, secret)}, nil
+}
+
+// ConfirmMFA turns two-factor authentication on with a first code from the enrolled
+// secret. It returns the recovery codes, which are shown only this once.
+func (s *AuthService) ConfirmMFA(userID, code string) ([]string, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.MFA == nil {
+		return nil, ErrMFANotEnrolled
+	}
+	step, ok := totp.Validate(user.MFA.Secret, strings.TrimSpace(code), time.Now(), 1)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := s.userRepo.SetMFA(user.ID, &models.MFA{
+		Secret:        user.MFA.Secret,
+		EnabledAt:     &now,
+		RecoveryCodes: hashes,
+		LastStep:      step,
+	}); err != nil {
+		return nil, err
+	}
+	log.Printf("[Auth] %s turned on two-factor authentication", user.Email)
+	return codes, nil
+}
+
+// EnrollMFAWithToken enrols a user whose sign-in is waiting for two-factor
+// authentication their role requires
+func (s *AuthService) EnrollMFAWithToken(mfaToken string) (*MFAEnrolment, error) {
+	user, err := s.pendingMFAUser(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.EnrollMFA(user.ID.Hex())
+}
+
+// ConfirmMFAEnrolment confirms the enrolment of EnrollMFAWithToken and completes the
+// sign-in. It returns the recovery codes along with the session. Wrong codes count as
+// they do for VerifyMFA.
+func (s *AuthService) ConfirmMFAEnrolment(mfaToken, code string, client Client) ([]string, *TokenPair, *models.User, error) {
+	user, err := s.pendingMFAUser(mfaToken)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := s.checkLockout(user.Email); err != nil {
+		return nil, nil, nil, err
+	}
+	codes, err := s.ConfirmMFA(user.ID.Hex(), code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			s.recordMFAFailure(mfaToken, user.Email)
+		}
+		return nil, nil, nil, err
+	}
+	s.clearLoginFailures(user.Email)
+	if user, err = s.GetProfile(user.ID.Hex()); err != nil {
+		return nil, nil, nil, err
+	}
+	tokens, err := s.startSession(user, client)
+	return codes, tokens, user, err
+}
+
+// DisableMFA turns two-factor authentication off, with the password and a current code.
+// Users whose role requires it can't.
+func (s *AuthService) DisableMFA(userID, password, code string) error {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled() {
+		return ErrMFANotEnrolled
+	}
+	if mfaRequired(user.Role) {
+		return ErrMFAMandatory
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	if err := s.checkMFACode(user, code); err != nil {
+		return err
+	}
+	if err := s.userRepo.SetMFA(user.ID, nil); err != nil {
+		return err
+	}
+	log.Printf("[Auth] %s turned off two-factor authentication", user.Email)
+	return nil
+}
+
+// pendingMFAUser returns the user of a valid MFA token that still has attempts left
+func (s *AuthService) pendingMFAUser(mfaToken string) (*models.User, error) {
+	userID, err := utils.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+	attempt, err := s.loginAttempts.GetLoginAttempt(mfaAttemptKey(mfaToken))
+	if err == nil && attempt.Failures >= MFAChallengeAttempts {
+		return nil, ErrInvalidMFAToken
+	}
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+	return user, nil
+}
+
+func mfaAttemptKey(mfaToken string) string {
+	return "mfa:" + hashToken(mfaToken)
+}
+
+// recordMFAFailure counts a wrong code against the user's address and against the MFA
+// token it came with
+func (s *AuthService) recordMFAFailure(mfaToken, email string) {
+	s.recordLoginFailure(email)
+	if _, err := s.loginAttempts.RecordFailure(mfaAttemptKey(mfaToken), utils.MFATokenTTL); err != nil {
+		log.Printf("[Auth] Failed to record a wrong two-factor code of %s: %v", email, err)
+	}
+}
+
+// checkMFACode accepts a TOTP code or an unused recovery code, once
+func (s *AuthService) checkMFACode(user *models.User, code string) error {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(user.MFA.Secret, code, time.Now(), 1); ok {
+		err := s.mfaCodes.UseMFAStep(user.ID, step)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrInvalidMFACode // Used already
+		}
+		return err
+	}
+
+	err := s.mfaCodes.UseRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrInvalidMFACode
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("[Auth] %s used a recovery code, %d left", user.Email, len(user.MFA.RecoveryCodes)-1)
+	return nil
+}
+
+// newRecoveryCodes returns RecoveryCodeCount codes like "k7q2-xm4p" and their hashes
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(b))
+		codes[i] = code[:4] + "-" + code[4:]
+		hashes[i] = hashToken(code)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode drops the dash and case, so "K7Q2 XM4P" works too
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/totp"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeMFACodes spends codes the way UserRepository does: steps only move forward and
+// each recovery code is removed when used
+type fakeMFACodes struct {
+	mu       sync.Mutex
+	lastStep map[primitive.ObjectID]int64
+	recovery map[primitive.ObjectID][]string
+}
+
+func (f *fakeMFACodes) UseMFAStep(id primitive.ObjectID, step int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if step <= f.lastStep[id] {
+		return mongo.ErrNoDocuments
+	}
+	f.lastStep[id] = step
+	return nil
+}
+
+func (f *fakeMFACodes) UseRecoveryCode(id primitive.ObjectID, codeHash string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, h := range f.recovery[id] {
+		if h == codeHash {
+			f.recovery[id] = append(f.recovery[id][:i], f.recovery[id][i+1:]...)
+			return nil
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+// fakeLoginAttempts keeps LoginAttemptRepository's documents in a map
+type fakeLoginAttempts struct {
+	mu       sync.Mutex
+	attempts map[string]*models.LoginAttempt
+}
+
+func (f *fakeLoginAttempts) GetLoginAttempt(key string) (*models.LoginAttempt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a, ok := f.attempts[key]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (f *fakeLoginAttempts) RecordFailure(key string, resetAfter time.Duration) (*models.LoginAttempt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a, ok := f.attempts[key]
+	if !ok || time.Since(a.LastFailureAt) > resetAfter {
+		a = &models.LoginAttempt{Email: key}
+		f.attempts[key] = a
+	}
+	a.Failures++
+	a.LastFailureAt = time.Now()
+	copied := *a
+	return &copied, nil
+}
+
+func (f *fakeLoginAttempts) LockUntil(key string, until time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if a, ok := f.attempts[key]; ok {
+		a.LockedUntil = &until
+	}
+	return nil
+}
+
+func (f *fakeLoginAttempts) ClearLoginAttempts(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.attempts, key)
+	return nil
+}
+
+// mfaUser returns a user with two-factor authentication on, its recovery codes and a
+// service whose code and login attempt stores are fakes
+func mfaUser(t *testing.T) (*AuthService, *models.User, []string) {
+	t.Helper()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	user := &models.User{
+		ID:    primitive.NewObjectID(),
+		Email: "official@example.com",
+		MFA:   &models.MFA{Secret: secret, EnabledAt: &now, RecoveryCodes: hashes},
+	}
+	s := &AuthService{
+		mfaCodes: &fakeMFACodes{
+			lastStep: map[primitive.ObjectID]int64{},
+			recovery: map[primitive.ObjectID][]string{user.ID: append([]string(nil), hashes...)},
+		},
+		loginAttempts: &fakeLoginAttempts{attempts: map[string]*models.LoginAttempt{}},
+	}
+	return s, user, codes
+}
+
+func TestCheckMFACodeRejectsReplay(t *testing.T) {
+	s, user, _ := mfaUser(t)
+	now := time.Now()
+	code, err := totp.Code(user.MFA.Secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.checkMFACode(user, code); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.checkMFACode(user, code); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("replayed code: got %v, want ErrInvalidMFACode", err)
+	}
+	// The previous step is still within the skew, but older than the code just used
+	previous, _ := totp.Code(user.MFA.Secret, now.Add(-totp.Period))
+	if err := s.checkMFACode(user, previous); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("code of an earlier step: got %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestRecoveryCodesWorkOnce(t *testing.T) {
+	s, user, codes := mfaUser(t)
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("got %d recovery codes, want %d", len(codes), RecoveryCodeCount)
+	}
+
+	if err := s.checkMFACode(user, codes[0]); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.checkMFACode(user, codes[0]); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("reused recovery code: got %v, want ErrInvalidMFACode", err)
+	}
+
+	// Typed in capitals with a space instead of the dash
+	typed := strings.ToUpper(strings.Replace(codes[1], "-", " ", 1))
+	if err := s.checkMFACode(user, typed); err != nil {
+		t.Errorf("%q: %v", typed, err)
+	}
+	if err := s.checkMFACode(user, "aaaa-aaaa"); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("unknown recovery code: got %v, want ErrInvalidMFACode", err)
+	}
+}
+
+func TestMFAChallengeAttempts(t *testing.T) {
+	t.Setenv("LOGIN_LOCKOUT_THRESHOLD", "10")
+	s, user, _ := mfaUser(t)
+	token, err := utils.GenerateMFAToken(user.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < MFAChallengeAttempts; i++ {
+		s.recordMFAFailure(token, user.Email)
+	}
+	if _, err := s.pendingMFAUser(token); !errors.Is(err, ErrInvalidMFAToken) {
+		t.Errorf("got %v after %d wrong codes, want ErrInvalidMFAToken", err, MFAChallengeAttempts)
+	}
+
+	// The wrong codes also count towards the address's lockout
+	attempt, err := s.loginAttempts.GetLoginAttempt(lockoutKey(user.Email))
+	if err != nil || attempt.Failures != MFAChallengeAttempts {
+		t.Errorf("got %+v (%v), want %d failures for the address", attempt, err, MFAChallengeAttempts)
+	}
+	for i := MFAChallengeAttempts; i < 10; i++ {
+		s.recordMFAFailure(token, user.Email)
+	}
+	var locked *AccountLockedError
+	if err := s.checkLockout(user.Email); !errors.As(err, &locked) {
+		t.Errorf("got %v after 10 wrong codes, want an AccountLockedError", err)
+	}
+}
+
+func TestMFARequired(t *testing.T) {
+	tests := []struct {
+		setting string
+		role    string
+		want    bool
+	}{
+		{"", models.RoleSuperAdmin, true},
+		{"", models.RoleAdmin, true},
+		{"", models.RoleMatchOfficial, true},
+		{"", models.RoleDataEditor, false},
+		{"", models.RoleUser, false},
+		{"MODERATOR, DATA_EDITOR", models.RoleDataEditor, true},
+		{"MODERATOR, DATA_EDITOR", models.RoleAdmin, false},
+		{"none", models.RoleSuperAdmin, false},
+	}
+	for _, tt := range tests {
+		t.Setenv("MFA_REQUIRED_ROLES", tt.setting)
+		if got := mfaRequired(tt.role); got != tt.want {
+			t.Errorf("MFA_REQUIRED_ROLES=%q: mfaRequired(%s) = %t, want %t", tt.setting, tt.role, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/oidc"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrOIDCDisabled        = errors.New("single sign-on is not configured")
+	ErrInvalidOIDCState    = errors.New("invalid or expired sign-in, start again")
+	ErrOIDCEmailUnverified = errors.New("the provider has not verified your email address")
+	ErrOIDCLinkUnverified  = errors.New("an account with this email address exists but the address is not verified; sign in with your password and verify it first")
+)
+
+// OIDCStateTTL is how long a user has to sign in at the provider
+const OIDCStateTTL = 10 * time.Minute
+
+// NewOIDCProvider returns the provider OIDC_ISSUER names, or nil if single sign-on is off
+func NewOIDCProvider(cfg *config.Config) (*oidc.Provider, error) {
+	if cfg.OIDCIssuer == "" {
+		return nil, nil
+	}
+	return oidc.NewProvider(oidc.Config{
+		Issuer:       cfg.OIDCIssuer,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		Scopes:       strings.Fields(cfg.OIDCScopes),
+	})
+}
+
+// OIDCEnabled reports whether users can sign in with the OIDC provider
+func (s *AuthService) OIDCEnabled() bool {
+	return s.oidc != nil
+}
+
+// StartOIDCLogin returns the provider URL to send the user to, and the state it carries.
+// The caller binds the state to the browser, so that only the browser that started a
+// sign-in can finish it. redirect is the app path to return to once signed in.
+func (s *AuthService) StartOIDCLogin(redirect string) (target, state string, err error) {
+	if s.oidc == nil {
+		return "", "", ErrOIDCDisabled
+	}
+
+	var values [3]string
+	for i := range values {
+		v, err := oidc.RandomString()
+		if err != nil {
+			return "", "", err
+		}
+		values[i] = v
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+
+	if err := s.oidcStates.CreateOIDCState(&models.OIDCState{
+		StateHash: hashToken(state),
+		Nonce:     nonce,
+		Verifier:  verifier,
+		Redirect:  safeRedirect(redirect),
+		ExpiresAt: time.Now().Add(OIDCStateTTL),
+	}); err != nil {
+		return "", "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	target, err = s.oidc.AuthURL(ctx, state, nonce, verifier)
+	return target, state, err
+}
+
+// FinishOIDCLogin completes a sign-in when the provider redirects back with code. The
+// user is found by their linked identity, or else by email address: an existing account
+// is linked if both the provider and the account have verified the address, and
+// otherwise a new USER account is made. It returns the app path to go on to. Users with
+// two-factor authentication get an MFAChallengeError, as from Login.
+func (s *AuthService) FinishOIDCLogin(state, code string, client Client) (*TokenPair, *models.User, string, error) {
+	if s.oidc == nil {
+		return nil, nil, "", ErrOIDCDisabled
+	}
+	saved, err := s.oidcStates.ConsumeOIDCState(hashToken(state))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil, "", ErrInvalidOIDCState
+	}
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	claims, err := s.oidc.Exchange(ctx, code, saved.Verifier, saved.Nonce)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	user, err := s.oidcUser(claims)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	tokens, err := s.signIn(user, client)
+	return tokens, user, saved.Redirect, err
+}
+
+func (s *AuthService) oidcUser(claims *oidc.Claims) (*models.User, error) {
+	user, err := s.userRepo.GetUserByIdentity(claims.Issuer, claims.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || !claims.EmailVerified {
+		return nil, ErrOIDCEmailUnverified
+	}
+	identity := models.ExternalIdentity{
+		Issuer:   claims.Issuer,
+		Subject:  claims.Subject,
+		Email:    email,
+		LinkedAt: time.Now(),
+	}
+
+	user, err = s.userRepo.GetUserByEmail(email)
+	switch {
+	case err == nil:
+		// Whoever controls an unverified address may not be the account's owner
+		if !user.EmailVerified() {
+			return nil, ErrOIDCLinkUnverified
+		}
+		if err := s.userRepo.AddIdentity(user.ID, identity); err != nil {
+			return nil, err
+		}
+		log.Printf("[Auth] Linked %s to %s at %s", user.Email, claims.Subject, claims.Issuer)
+		return user, nil
+	case !errors.Is(err, mongo.ErrNoDocuments):
+		return nil, err
+	}
+
+	// A random password keeps the account to single sign-on until the user resets it
+	password, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	name := claims.Name
+	if name == "" {
+		name = strings.SplitN(email, "@", 2)[0]
+	}
+	verified := identity.LinkedAt
+	user = &models.User{
+		Email:           email,
+		EmailVerifiedAt: &verified,
+		Password:        string(hashed),
+		FullName:        name,
+		Role:            models.RoleUser,
+		Identities:      []models.ExternalIdentity{identity},
+	}
+	if err := s.userRepo.CreateUser(user); err != nil {
+		return nil, err
+	}
+	log.Printf("[Auth] Created %s from %s at %s", email, claims.Subject, claims.Issuer)
+	return user, nil
+}
+
+// safeRedirect keeps redirect if it is a path in the app, so the callback can't be used
+// to send users to another site
+func safeRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.ContainsAny(redirect, "\\\r\n") {
+		return "/"
+	}
+	return redirect
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+)
+
+// MaxComparedPlayers is the maximum number of players in one comparison
+const MaxComparedPlayers = 4
+
+var ErrInvalidComparison = errors.New("compare needs between 1 and 4 distinct player ids")
+
+type ComparisonMetrics struct {
+	Appearances      int `json:"appearances"`
+	Goals            int `json:"goals"`
+	Assists          int `json:"assists"`
+	GoalInvolvements int `json:"goalInvolvements"`
+	CleanSheets      int `json:"cleanSheets"`
+	YellowCards      int `json:"yellowCards"`
+	RedCards         int `json:"redCards"`
+
+	// Per-90 figures cover the matches whose minutes are known from the lineups, and are
+	// left out when there are none
+	MinutesPlayed         *int     `json:"minutesPlayed,omitempty"`
+	GoalsPer90            *float64 `json:"goalsPer90,omitempty"`
+	AssistsPer90          *float64 `json:"assistsPer90,omitempty"`
+	GoalInvolvementsPer90 *float64 `json:"goalInvolvementsPer90,omitempty"`
+}
+
+type PlayerComparisonEntry struct {
+	Player        models.Player      `json:"player"`
+	TeamName      string             `json:"teamName"`
+	Age           *int               `json:"age"`
+	Metrics       ComparisonMetrics  `json:"metrics"`
+	Percentiles   map[string]float64 `json:"percentiles"` // Among players with the same position
+	PositionPeers int                `json:"positionPeers"`
+}
+
+type PlayerComparison struct {
+	Players []PlayerComparisonEntry `json:"players"`
+}
+
+// per90 normalises a count to a 90-minute rate, or nil if no minutes were played
+func per90(count, minutes int) *float64 {
+	if minutes <= 0 {
+		return nil
+	}
+	v := roundTo(float64(count)*90/float64(minutes), 2)
+	return &v
+}
+
+// timedTotals adds up the minutes, goals and assists of the matches in log whose minutes
+// are known. ok is false if there are none.
+func timedTotals(log []PlayerMatchEntry) (minutes, goals, assists int, ok bool) {
+	for _, e := range log {
+		if e.Minutes == nil {
+			continue
+		}
+		minutes += *e.Minutes
+		goals += e.Goals
+		assists += e.Assists
+		ok = true
+	}
+	return minutes, goals, assists, ok
+}
+
+// percentile returns the share of peers below value, counting ties as half
+func percentile(value int, peers []int) float64 {
+	if len(peers) == 0 {
+		return 0
+	}
+	below, equal := 0, 0
+	for _, v := range peers {
+		if v < value {
+			below++
+		} else if v == value {
+			equal++
+		}
+	}
+	return roundTo((float64(below)+float64(equal)/2)*100/float64(len(peers)), 1)
+}
+
+// ComparePlayers returns aligned season metrics for up to four players
+func (s *FootballService) ComparePlayers(ids []string) (*PlayerComparison, error) {
+	if len(ids) == 0 || len(ids) > MaxComparedPlayers {
+		return nil, ErrInvalidComparison
+	}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			return nil, ErrInvalidComparison
+		}
+		seen[id] = true
+	}
+
+	players := make([]*models.Player, 0, len(ids))
+	for _, id := range ids {
+		player, err := s.GetPlayerByID("", id)
+		if err != nil {
+			return nil, fmt.Errorf("player %s: %w", id, err)
+		}
+		players = append(players, player)
+	}
+
+	data, err := s.loadSeasonData("")
+	if err != nil {
+		return nil, err
+	}
+
+	peersByPosition := make(map[string][]models.Player)
+	comparison := &PlayerComparison{Players: []PlayerComparisonEntry{}}
+	for _, player := range players {
+		profile, err := s.buildPlayerProfile(player, data)
+		if err != nil {
+			return nil, err
+		}
+
+		involvements := profile.Totals.Goals + profile.Totals.Assists
+		entry := PlayerComparisonEntry{
+			Player:   *player,
+			TeamName: profile.TeamName,
+			Age:      profile.Age,
+			Metrics: ComparisonMetrics{
+				Appearances:      profile.Totals.Appearances,
+				Goals:            profile.Totals.Goals,
+				Assists:          profile.Totals.Assists,
+				GoalInvolvements: involvements,
+				CleanSheets:      profile.Totals.CleanSheets,
+				YellowCards:      profile.YellowCards,
+				RedCards:         profile.RedCards,
+			},
+			Percentiles: map[string]float64{},
+		}
+		if minutes, goals, assists, ok := timedTotals(profile.MatchLog); ok {
+			entry.Metrics.MinutesPlayed = &minutes
+			entry.Metrics.GoalsPer90 = per90(goals, minutes)
+			entry.Metrics.AssistsPer90 = per90(assists, minutes)
+			entry.Metrics.GoalInvolvementsPer90 = per90(goals+assists, minutes)
+		}
+
+		peers, ok := peersByPosition[player.Position]
+		if !ok {
+			peers, err = s.matchRepo.GetPlayersByPosition(player.Position)
+			if err != nil {
+				return nil, err
+			}
+			peersByPosition[player.Position] = peers
+		}
+		entry.PositionPeers = len(peers)
+		if len(peers) > 0 {
+			var goals, assists, involvementValues, cleanSheets []int
+			for _, p := range peers {
+				goals = append(goals, data.goals[p.ID])
+				assists = append(assists, data.assists[p.ID])
+				involvementValues = append(involvementValues, data.goals[p.ID]+data.assists[p.ID])
+				cleanSheets = append(cleanSheets, data.cleanSheets[p.ID])
+			}
+			entry.Percentiles["goals"] = percentile(data.goals[player.ID], goals)
+			entry.Percentiles["assists"] = percentile(data.assists[player.ID], assists)
+			entry.Percentiles["goalInvolvements"] = percentile(data.goals[player.ID]+data.assists[player.ID], involvementValues)
+			if isGoalkeeper(*player) {
+				entry.Percentiles["cleanSheets"] = percentile(data.cleanSheets[player.ID], cleanSheets)
+			}
+		}
+
+		comparison.Players = append(comparison.Players, entry)
+	}
+
+	return comparison, nil
+}
@@ -0,0 +1,465 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+)
+
+type PlayerMatchEntry struct {
+	MatchID       string    `json:"matchId"`
+	Matchday      int       `json:"matchday"`
+	Date          time.Time `json:"date"`
+	OpponentID    string    `json:"opponentId"`
+	OpponentName  string    `json:"opponentName"`
+	IsHome        bool      `json:"isHome"`
+	TeamScore     int       `json:"teamScore"`
+	OpponentScore int       `json:"opponentScore"`
+	Result        string    `json:"result"` // W, D, L
+	Goals         int       `json:"goals"`
+	Assists       int       `json:"assists"`
+	YellowCards   int       `json:"yellowCards"`
+	RedCards      int       `json:"redCards"`
+	CleanSheet    bool      `json:"cleanSheet"`
+	Minutes       *int      `json:"minutes,omitempty"` // nil when the team recorded no lineup
+	// Set when the team recorded no lineup and the player is not in the match's events,
+	// so the row is one of their team's fixtures rather than a known appearance
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// PlayerSplit counts appearances, results and clean sheets from known appearances only;
+// fixtures the player may have missed are counted in Estimated
+type PlayerSplit struct {
+	Appearances int `json:"appearances"`
+	Estimated   int `json:"estimated"` // Team fixtures without a lineup to check
+	Goals       int `json:"goals"`
+	Assists     int `json:"assists"`
+	CleanSheets int `json:"cleanSheets"`
+	Wins        int `json:"wins"`
+	Draws       int `json:"draws"`
+	Losses      int `json:"losses"`
+}
+
+type OpponentRecord struct {
+	OpponentID   string `json:"opponentId"`
+	OpponentName string `json:"opponentName"`
+	Appearances  int    `json:"appearances"`
+	Goals        int    `json:"goals"`
+	Assists      int    `json:"assists"`
+}
+
+type Streak struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+type PlayerStreaks struct {
+	Scoring         Streak `json:"scoring"`         // Consecutive matches with a goal
+	GoalInvolvement Streak `json:"goalInvolvement"` // Consecutive matches with a goal or assist
+	CleanSheets     Streak `json:"cleanSheets"`
+}
+
+// LeaderboardRank is a player's competition-style position (1, 2, 2, 4) on a leaderboard
+type LeaderboardRank struct {
+	Value int `json:"value"`
+	Rank  int `json:"rank"`
+	Of    int `json:"of"` // Number of players on the leaderboard
+}
+
+type PlayerRanks struct {
+	Goals       *LeaderboardRank `json:"goals,omitempty"`
+	Assists     *LeaderboardRank `json:"assists,omitempty"`
+	CleanSheets *LeaderboardRank `json:"cleanSheets,omitempty"` // Goalkeepers only
+}
+
+type PlayerProfile struct {
+	Player      models.Player      `json:"player"`
+	TeamName    string             `json:"teamName"`
+	Age         *int               `json:"age"`
+	Totals      PlayerSplit        `json:"totals"`
+	Home        PlayerSplit        `json:"home"`
+	Away        PlayerSplit        `json:"away"`
+	YellowCards int                `json:"yellowCards"`
+	RedCards    int                `json:"redCards"`
+	Streaks     PlayerStreaks      `json:"streaks"`
+	Opponents   []OpponentRecord   `json:"opponents"`
+	Ranks       PlayerRanks        `json:"ranks"`
+	MatchLog    []PlayerMatchEntry `json:"matchLog"`
+}
+
+// isGoalkeeper reports whether a player's position is goalkeeper
+func isGoalkeeper(p models.Player) bool {
+	return strings.Contains(strings.ToLower(p.Position), "goalkeeper")
+}
+
+// ageOn returns the age in whole years on the given day for a YYYY-MM-DD date of birth
+func ageOn(dateOfBirth string, on time.Time) *int {
+	dob, err := time.Parse("2006-01-02", dateOfBirth)
+	if err != nil {
+		return nil
+	}
+	age := on.Year() - dob.Year()
+	if on.Month() < dob.Month() || (on.Month() == dob.Month() && on.Day() < dob.Day()) {
+		age--
+	}
+	return &age
+}
+
+// competitionRank ranks value among all values (1 + number of strictly greater values)
+func competitionRank(value int, all []int) *LeaderboardRank {
+	rank := 1
+	for _, v := range all {
+		if v > value {
+			rank++
+		}
+	}
+	return &LeaderboardRank{Value: value, Rank: rank, Of: len(all)}
+}
+
+func (s *PlayerSplit) add(e PlayerMatchEntry) {
+	s.Goals += e.Goals
+	s.Assists += e.Assists
+	if e.Estimated {
+		s.Estimated++
+		return
+	}
+	s.Appearances++
+	if e.CleanSheet {
+		s.CleanSheets++
+	}
+	switch e.Result {
+	case "W":
+		s.Wins++
+	case "D":
+		s.Draws++
+	default:
+		s.Losses++
+	}
+}
+
+// streakOf measures a streak over the match log (oldest first)
+func streakOf(log []PlayerMatchEntry, hit func(PlayerMatchEntry) bool) Streak {
+	var s Streak
+	for _, e := range log {
+		if hit(e) {
+			s.Current++
+			if s.Current > s.Longest {
+				s.Longest = s.Current
+			}
+		} else {
+			s.Current = 0
+		}
+	}
+	return s
+}
+
+// seasonData holds everything the player endpoints aggregate over, loaded once per request
+type seasonData struct {
+	matches     []models.Match
+	goalEvents  []models.GoalEvent
+	teamNames   map[string]string
+	goals       map[string]int                 // Player ID -> goals
+	assists     map[string]int                 // Player ID -> assists
+	cleanSheets map[string]int                 // Player ID -> clean sheets in matches they started
+	subs        map[string][]models.MatchEvent // Match ID -> substitutions
+}
+
+// loadSeasonData loads a season's data; an empty season is the active one
+func (s *FootballService) loadSeasonData(season string) (*seasonData, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.matchRepo.GetFinishedMatches(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	goalEvents, err := s.matchRepo.GetAllGoalEvents(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	matchIDs := make([]string, len(matches))
+	for i, m := range matches {
+		matchIDs[i] = m.ID
+	}
+	subs, err := s.matchRepo.GetSubstitutionEvents(matchIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &seasonData{
+		matches:     matches,
+		goalEvents:  goalEvents,
+		teamNames:   make(map[string]string),
+		goals:       make(map[string]int),
+		assists:     make(map[string]int),
+		cleanSheets: make(map[string]int),
+		subs:        make(map[string][]models.MatchEvent),
+	}
+	for _, e := range subs {
+		data.subs[e.MatchID] = append(data.subs[e.MatchID], e)
+	}
+	for _, t := range teams {
+		data.teamNames[t.ID] = t.Name
+	}
+	for _, e := range goalEvents {
+		if e.ScorerID != "" {
+			data.goals[e.ScorerID]++
+		}
+		if e.AssistID != "" {
+			data.assists[e.AssistID]++
+		}
+	}
+	for _, m := range matches {
+		if m.AwayScore == 0 {
+			for _, id := range m.HomeLineup {
+				data.cleanSheets[id]++
+			}
+		}
+		if m.HomeScore == 0 {
+			for _, id := range m.AwayLineup {
+				data.cleanSheets[id]++
+			}
+		}
+	}
+	return data, nil
+}
+
+// GetPlayerProfile builds a season profile for a player from goal_events, match_events and matches
+func (s *FootballService) GetPlayerProfile(playerID, season string) (*PlayerProfile, error) {
+	player, err := s.matchRepo.GetPlayerByID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.loadSeasonData(season)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildPlayerProfile(player, data)
+}
+
+func (s *FootballService) buildPlayerProfile(player *models.Player, data *seasonData) (*PlayerProfile, error) {
+	playerID := player.ID
+	cardEvents, err := s.matchRepo.GetPlayerMatchEvents(playerID)
+	if err != nil {
+		return nil, err
+	}
+	teamNames := data.teamNames
+
+	profile := &PlayerProfile{
+		Player:    *player,
+		TeamName:  teamNames[player.TeamID],
+		Age:       ageOn(player.DateOfBirth, time.Now()),
+		Opponents: []OpponentRecord{},
+		MatchLog:  []PlayerMatchEntry{},
+	}
+
+	// Index this player's goal involvements by match ID, or by matchday and team for seeded
+	// events, along with the team they were involved for
+	goalsByMatch := make(map[string]int)
+	assistsByMatch := make(map[string]int)
+	involved := make(map[string]string)
+	for _, e := range data.goalEvents {
+		key := e.MatchID
+		if key == "" {
+			key = dayTeamKey(e.Matchday, e.TeamID)
+		}
+		if e.ScorerID == playerID {
+			goalsByMatch[key]++
+			involved[key] = e.TeamID
+		}
+		if e.AssistID == playerID {
+			assistsByMatch[key]++
+			involved[key] = e.TeamID
+		}
+	}
+
+	yellowsByMatch := make(map[string]int)
+	redsByMatch := make(map[string]int)
+	sentOffAt := make(map[string]int)
+	for _, e := range cardEvents {
+		switch e.Type {
+		case models.YellowCard:
+			yellowsByMatch[e.MatchID]++
+		case models.RedCard:
+			if redsByMatch[e.MatchID] == 0 {
+				sentOffAt[e.MatchID] = e.Minute
+			}
+			redsByMatch[e.MatchID]++
+		}
+		if _, ok := involved[e.MatchID]; !ok || e.TeamID != "" {
+			involved[e.MatchID] = e.TeamID
+		}
+	}
+
+	opponents := make(map[string]*OpponentRecord)
+	for _, m := range data.matches {
+		teamID, estimated, ok := appearance(m, player, involved)
+		if !ok {
+			continue
+		}
+		seededKey := dayTeamKey(m.Matchday, teamID)
+		entry := PlayerMatchEntry{
+			MatchID:     m.ID,
+			Matchday:    m.Matchday,
+			Date:        m.Date,
+			IsHome:      m.HomeTeamID == teamID,
+			Goals:       goalsByMatch[m.ID] + goalsByMatch[seededKey],
+			Assists:     assistsByMatch[m.ID] + assistsByMatch[seededKey],
+			YellowCards: yellowsByMatch[m.ID],
+			RedCards:    redsByMatch[m.ID],
+			Estimated:   estimated,
+		}
+		if entry.IsHome {
+			entry.OpponentID = m.AwayTeamID
+			entry.TeamScore, entry.OpponentScore = m.HomeScore, m.AwayScore
+		} else {
+			entry.OpponentID = m.HomeTeamID
+			entry.TeamScore, entry.OpponentScore = m.AwayScore, m.HomeScore
+		}
+		if !estimated {
+			off := FullTime
+			if minute, ok := sentOffAt[m.ID]; ok {
+				off = minute
+			}
+			entry.Minutes = minutesPlayed(m, teamID, playerID, data.subs[m.ID], off)
+		}
+		entry.OpponentName = teamNames[entry.OpponentID]
+		entry.CleanSheet = !estimated && entry.OpponentScore == 0
+		switch {
+		case entry.TeamScore > entry.OpponentScore:
+			entry.Result = "W"
+		case entry.TeamScore == entry.OpponentScore:
+			entry.Result = "D"
+		default:
+			entry.Result = "L"
+		}
+
+		profile.MatchLog = append(profile.MatchLog, entry)
+		profile.Totals.add(entry)
+		if entry.IsHome {
+			profile.Home.add(entry)
+		} else {
+			profile.Away.add(entry)
+		}
+		profile.YellowCards += entry.YellowCards
+		profile.RedCards += entry.RedCards
+
+		opp, ok := opponents[entry.OpponentID]
+		if !ok {
+			opp = &OpponentRecord{OpponentID: entry.OpponentID, OpponentName: entry.OpponentName}
+			opponents[entry.OpponentID] = opp
+		}
+		if !estimated {
+			opp.Appearances++
+		}
+		opp.Goals += entry.Goals
+		opp.Assists += entry.Assists
+	}
+
+	for _, opp := range opponents {
+		profile.Opponents = append(profile.Opponents, *opp)
+	}
+	sort.Slice(profile.Opponents, func(i, j int) bool {
+		if profile.Opponents[i].Goals != profile.Opponents[j].Goals {
+			return profile.Opponents[i].Goals > profile.Opponents[j].Goals
+		}
+		return profile.Opponents[i].OpponentName < profile.Opponents[j].OpponentName
+	})
+
+	// Matches are loaded in matchday order, but a rescheduled match is played out of it
+	sort.SliceStable(profile.MatchLog, func(i, j int) bool {
+		return profile.MatchLog[i].Date.Before(profile.MatchLog[j].Date)
+	})
+	profile.Streaks = PlayerStreaks{
+		Scoring:         streakOf(profile.MatchLog, func(e PlayerMatchEntry) bool { return e.Goals > 0 }),
+		GoalInvolvement: streakOf(profile.MatchLog, func(e PlayerMatchEntry) bool { return e.Goals+e.Assists > 0 }),
+		CleanSheets:     streakOf(profile.MatchLog, func(e PlayerMatchEntry) bool { return e.CleanSheet }),
+	}
+
+	profile.Ranks, err = s.playerRanks(player, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Most recent match first, like the team form guide
+	for i, j := 0, len(profile.MatchLog)-1; i < j; i, j = i+1, j-1 {
+		profile.MatchLog[i], profile.MatchLog[j] = profile.MatchLog[j], profile.MatchLog[i]
+	}
+
+	return profile, nil
+}
+
+// appearance works out whether player took part in m and for which team. The lineups
+// and the player's events in the match say so where they are recorded; otherwise a
+// fixture of the player's current team is returned as an estimate.
+func appearance(m models.Match, player *models.Player, involved map[string]string) (teamID string, estimated, ok bool) {
+	for _, side := range []struct {
+		teamID string
+		lineup []string
+	}{{m.HomeTeamID, m.HomeLineup}, {m.AwayTeamID, m.AwayLineup}} {
+		for _, id := range side.lineup {
+			if id == player.ID {
+				return side.teamID, false, true
+			}
+		}
+		// Came on as a substitute, or a seeded goal event
+		if team, found := involved[dayTeamKey(m.Matchday, side.teamID)]; found && team == side.teamID {
+			return side.teamID, false, true
+		}
+		if team, found := involved[m.ID]; found && (team == side.teamID || (team == "" && player.TeamID == side.teamID)) {
+			return side.teamID, false, true
+		}
+	}
+
+	switch player.TeamID {
+	case m.HomeTeamID:
+		return m.HomeTeamID, true, len(m.HomeLineup) == 0
+	case m.AwayTeamID:
+		return m.AwayTeamID, true, len(m.AwayLineup) == 0
+	}
+	return "", false, false
+}
+
+// playerRanks places the player on the goals, assists and (for goalkeepers) clean sheet leaderboards
+func (s *FootballService) playerRanks(player *models.Player, data *seasonData) (PlayerRanks, error) {
+	var ranks PlayerRanks
+
+	if data.goals[player.ID] > 0 {
+		ranks.Goals = competitionRank(data.goals[player.ID], mapValues(data.goals))
+	}
+	if data.assists[player.ID] > 0 {
+		ranks.Assists = competitionRank(data.assists[player.ID], mapValues(data.assists))
+	}
+
+	if !isGoalkeeper(*player) || data.cleanSheets[player.ID] == 0 {
+		return ranks, nil
+	}
+
+	keepers, err := s.matchRepo.GetGoalkeepers()
+	if err != nil {
+		return ranks, err
+	}
+	var cleanSheetValues []int
+	for _, p := range keepers {
+		if data.cleanSheets[p.ID] > 0 {
+			cleanSheetValues = append(cleanSheetValues, data.cleanSheets[p.ID])
+		}
+	}
+	ranks.CleanSheets = competitionRank(data.cleanSheets[player.ID], cleanSheetValues)
+	return ranks, nil
+}
+
+func mapValues(m map[string]int) []int {
+	values := make([]int, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// DeletedUserName replaces the name on the reviews of a deleted account
+const DeletedUserName = "Deleted user"
+
+// ProfileUpdate holds the fields to change; nil fields stay as they are
+type ProfileUpdate struct {
+	FullName *string
+	Email    *string
+	Password string // Current password, required to change the email address
+}
+
+func (s *AuthService) GetProfile(userID string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	return user, nil
+}
+
+// UpdateProfile changes the user's name or email address. A new address has to be
+// verified again, and a verification link is sent to it.
+func (s *AuthService) UpdateProfile(userID string, update ProfileUpdate) (*models.User, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.FullName != nil {
+		name := strings.TrimSpace(*update.FullName)
+		if name == "" {
+			return nil, fmt.Errorf("%w: fullName can't be empty", ErrInvalidProfile)
+		}
+		user.FullName = name
+	}
+
+	emailChanged := false
+	if update.Email != nil && strings.TrimSpace(*update.Email) != user.Email {
+		email := strings.TrimSpace(*update.Email)
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(update.Password)); err != nil {
+			return nil, ErrInvalidCredentials
+		}
+		if _, err := s.userRepo.GetUserByEmail(email); err == nil {
+			return nil, ErrUserExists
+		} else if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		log.Printf("[Auth] %s changed their email address to %s", user.Email, email)
+		user.Email = email
+		user.EmailVerifiedAt = nil
+		emailChanged = true
+	}
+
+	if err := s.userRepo.UpdateProfile(user); err != nil {
+		return nil, err
+	}
+	if emailChanged {
+		if err := s.sendVerification(user); err != nil {
+			log.Printf("[Auth] Failed to send the verification email to %s: %v", user.Email, err)
+		}
+	}
+	return user, nil
+}
+
+// ChangePassword sets a new password once the current one is checked. Every session is
+// signed out, and the caller gets a fresh one.
+func (s *AuthService) ChangePassword(userID, current, password string, client Client) (*TokenPair, error) {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(current)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdatePassword(user.ID, string(hashed)); err != nil {
+		return nil, err
+	}
+	if _, err := s.LogoutAll(userID); err != nil {
+		log.Printf("[Auth] Failed to end the sessions of %s: %v", user.Email, err)
+	}
+	return s.startSession(user, client)
+}
+
+// DeleteAccount soft-deletes the user: they can no longer sign in or be looked up, their
+// sessions end, their API keys are revoked, and their reviews stay under an anonymous name.
+func (s *AuthService) DeleteAccount(userID, password string) error {
+	user, err := s.GetProfile(userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return ErrInvalidCredentials
+	}
+	if err := s.checkNotLastManager(user); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SoftDelete(user.ID); err != nil {
+		return err
+	}
+	n, err := s.reviews.AnonymiseUser(userID, DeletedUserName)
+	if err != nil {
+		log.Printf("[Auth] Failed to anonymise the reviews of %s: %v", user.Email, err)
+	}
+	if _, err := s.LogoutAll(userID); err != nil {
+		log.Printf("[Auth] Failed to end the sessions of %s: %v", user.Email, err)
+	}
+	if _, err := s.apiKeys.RevokeUserAPIKeys(userID); err != nil {
+		log.Printf("[Auth] Failed to revoke the API keys of %s: %v", user.Email, err)
+	}
+	log.Printf("[Auth] %s deleted their account (%d reviews anonymised)", user.Email, n)
+	return nil
+}
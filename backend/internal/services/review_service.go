@@ -17,11 +17,14 @@ func NewReviewService() *ReviewService {
 	}
 }
 
-func (s *ReviewService) CreateReview(email, content string, rating int, matchID, teamID, playerID string) error {
-	user, err := s.userRepo.GetUserByEmail(email)
+func (s *ReviewService) CreateReview(userID, content string, rating int, matchID, teamID, playerID string) error {
+	user, err := s.userRepo.GetUserByID(userID)
 	if err != nil {
 		return err
 	}
+	if !user.EmailVerified() {
+		return ErrEmailNotVerified
+	}
 
 	review := &models.Review{
 		UserID:   user.ID.Hex(),
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+)
+
+const (
+	schedulerLockName = "kickoff-scheduler"
+	// A LIVE match with no simulation this long after kickoff lost its simulation in a restart
+	orphanedLiveAfter = 10 * time.Minute
+	// How many upcoming kickoffs the queue endpoint lists
+	schedulerQueueSize = 20
+	// How many recent starts and finishes the queue endpoint remembers
+	schedulerHistorySize = 20
+	// How many missed kickoffs the queue endpoint lists
+	schedulerMissedSize = 50
+)
+
+// Clock is the league's idea of the current time. On real time it is time.Now();
+// a virtual clock starts at a chosen date and runs speed times faster, so a demo can
+// play through a matchday in minutes.
+type Clock struct {
+	start  time.Time // League time when the clock was created
+	origin time.Time // Real time when the clock was created
+	speed  float64
+}
+
+// NewClock returns a clock reading start now and advancing speed league seconds per
+// real second. A zero start means real time.
+func NewClock(start time.Time, speed float64) *Clock {
+	now := time.Now()
+	if start.IsZero() {
+		start = now
+	}
+	return &Clock{start: start, origin: now, speed: speed}
+}
+
+// Now returns the current league time
+func (c *Clock) Now() time.Time {
+	elapsed := time.Since(c.origin)
+	return c.start.Add(time.Duration(float64(elapsed) * c.speed))
+}
+
+// Until returns the real time left until the clock reads t
+func (c *Clock) Until(t time.Time) time.Duration {
+	return time.Duration(float64(t.Sub(c.Now())) / c.speed)
+}
+
+// Virtual reports whether the clock runs on anything but real time
+func (c *Clock) Virtual() bool {
+	return c.speed != 1 || c.start.Sub(c.origin).Abs() > time.Second
+}
+
+type QueuedKickoff struct {
+	MatchID    string    `json:"matchId"`
+	SeasonID   string    `json:"seasonId"`
+	Matchday   int       `json:"matchday"`
+	HomeTeamID string    `json:"homeTeamId"`
+	AwayTeamID string    `json:"awayTeamId"`
+	Kickoff    time.Time `json:"kickoff"`
+	StartsIn   string    `json:"startsIn"` // Real time until kickoff; negative when overdue
+}
+
+type LiveMatch struct {
+	MatchID    string     `json:"matchId"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	Simulating bool       `json:"simulating"` // Whether this instance is running its simulation
+}
+
+type SchedulerAction struct {
+	At      time.Time `json:"at"`
+	MatchID string    `json:"matchId"`
+	Action  string    `json:"action"` // "started" or "finished"
+	Error   string    `json:"error,omitempty"`
+}
+
+type SchedulerStatus struct {
+	Enabled       bool              `json:"enabled"`
+	InstanceID    string            `json:"instanceId,omitempty"`
+	Leader        bool              `json:"leader"`
+	LockOwner     string            `json:"lockOwner,omitempty"`
+	LockExpiresAt *time.Time        `json:"lockExpiresAt,omitempty"`
+	Clock         time.Time         `json:"clock"`
+	ClockSpeed    float64           `json:"clockSpeed"`
+	VirtualClock  bool              `json:"virtualClock"`
+	Interval      string            `json:"interval,omitempty"`
+	LastScan      *time.Time        `json:"lastScan,omitempty"`
+	LastError     string            `json:"lastError,omitempty"`
+	CatchUp       string            `json:"catchUp,omitempty"`
+	MaxStarts     int               `json:"maxStarts,omitempty"`
+	Queue         []QueuedKickoff   `json:"queue"`
+	Missed        []QueuedKickoff   `json:"missed"` // Overdue past the catch-up window; started or rescheduled by hand
+	Live          []LiveMatch       `json:"live"`
+	Recent        []SchedulerAction `json:"recent"`
+}
+
+// KickoffScheduler starts SCHEDULED matches when the clock reaches their date; the
+// simulation then finishes them. It keeps no state of its own: every scan reads the
+// matches collection, so a restart picks up where the last run left off. Matches
+// overdue by more than the catch-up window are never started automatically, so
+// turning it on over old fixtures doesn't play them all at once; they are listed as
+// missed instead. When several server instances run, a lease in the locks collection
+// lets only one of them act.
+type KickoffScheduler struct {
+	matchRepo  *repositories.MatchRepository
+	locks      *repositories.LockRepository
+	football   *FootballService
+	clock      *Clock
+	interval   time.Duration
+	catchUp    time.Duration // In league time
+	maxStarts  int
+	instanceID string
+	stop       chan struct{}
+
+	mu        sync.Mutex
+	leader    bool
+	lastScan  time.Time
+	lastError string
+	recent    []SchedulerAction
+}
+
+var (
+	kickoffScheduler   *KickoffScheduler
+	kickoffSchedulerMu sync.Mutex
+)
+
+// GetKickoffScheduler returns the running scheduler, or nil if it is disabled
+func GetKickoffScheduler() *KickoffScheduler {
+	kickoffSchedulerMu.Lock()
+	defer kickoffSchedulerMu.Unlock()
+	return kickoffScheduler
+}
+
+// NewKickoffScheduler builds a scheduler from the SCHEDULER_* and CLOCK_* settings
+func NewKickoffScheduler(cfg *config.Config) (*KickoffScheduler, error) {
+	if cfg.SchedulerInterval <= 0 {
+		return nil, fmt.Errorf("SCHEDULER_INTERVAL must be positive, got %d", cfg.SchedulerInterval)
+	}
+	if cfg.ClockSpeed <= 0 {
+		return nil, fmt.Errorf("CLOCK_SPEED must be positive, got %g", cfg.ClockSpeed)
+	}
+	if cfg.SchedulerCatchUp <= 0 || cfg.SchedulerMaxStart <= 0 {
+		return nil, fmt.Errorf("SCHEDULER_CATCH_UP and SCHEDULER_MAX_STARTS must be positive, got %d and %d",
+			cfg.SchedulerCatchUp, cfg.SchedulerMaxStart)
+	}
+	var start time.Time
+	if cfg.ClockStart != "" {
+		t, err := time.Parse(time.RFC3339, cfg.ClockStart)
+		if err != nil {
+			return nil, fmt.Errorf("CLOCK_START must be an RFC 3339 time: %w", err)
+		}
+		start = t
+	}
+
+	return &KickoffScheduler{
+		matchRepo:  repositories.NewMatchRepository(),
+		locks:      repositories.NewLockRepository(),
+		football:   NewFootballService(),
+		clock:      NewClock(start, cfg.ClockSpeed),
+		interval:   time.Duration(cfg.SchedulerInterval) * time.Second,
+		catchUp:    time.Duration(cfg.SchedulerCatchUp) * time.Hour,
+		maxStarts:  cfg.SchedulerMaxStart,
+		instanceID: newInstanceID(),
+		stop:       make(chan struct{}),
+	}, nil
+}
+
+// Start runs the scheduler in the background until Stop is called
+func (s *KickoffScheduler) Start() {
+	kickoffSchedulerMu.Lock()
+	kickoffScheduler = s
+	kickoffSchedulerMu.Unlock()
+
+	log.Printf("[Scheduler] Instance %s scanning every %s, clock at %s (x%g), catching up %s",
+		s.instanceID, s.interval, s.clock.Now().Format(time.RFC3339), s.clock.speed, s.catchUp)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.scan()
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the scan loop and hands the lease to the next instance
+func (s *KickoffScheduler) Stop() {
+	close(s.stop)
+	if err := s.locks.Release(schedulerLockName, s.instanceID); err != nil {
+		log.Printf("[Scheduler] Failed to release lock: %v", err)
+	}
+}
+
+// scan starts the matches that are due and finishes LIVE matches whose simulation was lost
+func (s *KickoffScheduler) scan() {
+	// The lease outlives a few missed scans, so a slow scan doesn't hand it over
+	leader, err := s.locks.Acquire(schedulerLockName, s.instanceID, 3*s.interval)
+	if err == nil && leader {
+		err = s.startDueMatches()
+		if err == nil {
+			err = s.finishOrphanedMatches()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if leader != s.leader {
+		log.Printf("[Scheduler] Instance %s leader: %v", s.instanceID, leader)
+	}
+	s.leader = leader
+	s.lastScan = time.Now()
+	s.lastError = ""
+	if err != nil {
+		log.Printf("[Scheduler] Scan failed: %v", err)
+		s.lastError = err.Error()
+	}
+}
+
+// startDueMatches starts up to maxStarts matches that kicked off within the catch-up
+// window; the rest wait for the next scan
+func (s *KickoffScheduler) startDueMatches() error {
+	now := s.clock.Now()
+	due, err := s.matchRepo.GetScheduledBetween(now.Add(-s.catchUp), now, int64(s.maxStarts))
+	if err != nil {
+		return err
+	}
+	for _, m := range due {
+		// Only one caller wins the SCHEDULED -> LIVE update, so a match started by
+		// hand or by another instance is left alone
+		started, err := s.matchRepo.StartMatchIfScheduled(m.ID, time.Now())
+		if err != nil {
+			s.record(m.ID, "started", err)
+			continue
+		}
+		if !started {
+			continue
+		}
+		SimulateMatch(m.ID)
+		log.Printf("[Scheduler] Kicked off %s (matchday %d, due %s)", m.ID, m.Matchday, m.Date.Format(time.RFC3339))
+		s.record(m.ID, "started", nil)
+	}
+	return nil
+}
+
+// finishOrphanedMatches finishes LIVE matches whose simulation died with the instance
+// running it, keeping the events recorded before it stopped. Matches started before
+// the scheduler existed have no start time and are left to an admin.
+func (s *KickoffScheduler) finishOrphanedMatches() error {
+	live, err := s.matchRepo.GetLiveMatches()
+	if err != nil {
+		return err
+	}
+	for _, m := range live {
+		if m.StartedAt == nil || time.Since(*m.StartedAt) < orphanedLiveAfter || IsSimulationRunning(m.ID) {
+			continue
+		}
+		err := s.football.FinishMatch(m.ID)
+		if err == nil {
+			log.Printf("[Scheduler] Finished %s, whose simulation was lost", m.ID)
+		}
+		s.record(m.ID, "finished", err)
+	}
+	return nil
+}
+
+func (s *KickoffScheduler) record(matchID, action string, err error) {
+	entry := SchedulerAction{At: time.Now(), MatchID: matchID, Action: action}
+	if err != nil {
+		log.Printf("[Scheduler] Failed to %s %s: %v", action, matchID, err)
+		entry.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recent = append([]SchedulerAction{entry}, s.recent...)
+	if len(s.recent) > schedulerHistorySize {
+		s.recent = s.recent[:schedulerHistorySize]
+	}
+}
+
+// Status returns the scheduler's clock, leadership, the next kickoffs, the missed ones
+// and the live matches
+func (s *KickoffScheduler) Status() (*SchedulerStatus, error) {
+	cutoff := s.clock.Now().Add(-s.catchUp)
+	upcoming, err := s.matchRepo.GetScheduledBetween(cutoff, time.Time{}, schedulerQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	missed, err := s.matchRepo.GetScheduledBetween(time.Time{}, cutoff, schedulerMissedSize)
+	if err != nil {
+		return nil, err
+	}
+	live, err := s.matchRepo.GetLiveMatches()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := s.locks.GetLock(schedulerLockName)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SchedulerStatus{
+		Enabled:      true,
+		InstanceID:   s.instanceID,
+		Clock:        s.clock.Now(),
+		ClockSpeed:   s.clock.speed,
+		VirtualClock: s.clock.Virtual(),
+		Interval:     s.interval.String(),
+		CatchUp:      s.catchUp.String(),
+		MaxStarts:    s.maxStarts,
+		Queue:        make([]QueuedKickoff, 0, len(upcoming)),
+		Missed:       make([]QueuedKickoff, 0, len(missed)),
+		Live:         make([]LiveMatch, 0, len(live)),
+	}
+	if lock != nil && lock.ExpiresAt.After(time.Now()) {
+		status.LockOwner = lock.Owner
+		status.LockExpiresAt = &lock.ExpiresAt
+	}
+	for _, m := range upcoming {
+		status.Queue = append(status.Queue, s.queued(m))
+	}
+	for _, m := range missed {
+		status.Missed = append(status.Missed, s.queued(m))
+	}
+	for _, m := range live {
+		status.Live = append(status.Live, LiveMatch{
+			MatchID:    m.ID,
+			StartedAt:  m.StartedAt,
+			Simulating: IsSimulationRunning(m.ID),
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status.Leader = s.leader
+	if !s.lastScan.IsZero() {
+		lastScan := s.lastScan
+		status.LastScan = &lastScan
+	}
+	status.LastError = s.lastError
+	status.Recent = append([]SchedulerAction{}, s.recent...)
+	return status, nil
+}
+
+func (s *KickoffScheduler) queued(m models.Match) QueuedKickoff {
+	return QueuedKickoff{
+		MatchID:    m.ID,
+		SeasonID:   m.SeasonID,
+		Matchday:   m.Matchday,
+		HomeTeamID: m.HomeTeamID,
+		AwayTeamID: m.AwayTeamID,
+		Kickoff:    m.Date,
+		StartsIn:   s.clock.Until(m.Date).Round(time.Second).String(),
+	}
+}
+
+// DisabledSchedulerStatus is reported when SCHEDULER_ENABLED is off
+func DisabledSchedulerStatus() *SchedulerStatus {
+	return &SchedulerStatus{
+		Clock:      time.Now(),
+		ClockSpeed: 1,
+		Queue:      []QueuedKickoff{},
+		Missed:     []QueuedKickoff{},
+		Live:       []LiveMatch{},
+		Recent:     []SchedulerAction{},
+	}
+}
+
+// newInstanceID names this process in the locks collection
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	b := make([]byte, 4)
+	rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DefaultSeasonID is used until a season has been marked active in the seasons collection
+const DefaultSeasonID = "2025-26"
+
+// RelegationPlaces is how many clubs go down, and come up, at the end of a season
+const RelegationPlaces = 3
+
+var (
+	ErrUnknownSeason   = errors.New("unknown season")
+	ErrInvalidRollover = errors.New("invalid season rollover")
+)
+
+// ResolveSeasonID turns a ?season= value into a season ID: empty means the active
+// season, and "2025/26" is accepted as well as "2025-26".
+func ResolveSeasonID(seasonID string) (string, error) {
+	seasonRepo := repositories.NewSeasonRepository()
+	seasonID = models.NormalizeSeasonID(seasonID)
+
+	if seasonID == "" {
+		active, err := seasonRepo.GetActiveSeason()
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return DefaultSeasonID, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return active.ID, nil
+	}
+
+	if _, err := seasonRepo.GetSeasonByID(seasonID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			if seasonID == DefaultSeasonID {
+				return seasonID, nil
+			}
+			return "", fmt.Errorf("%w: %s", ErrUnknownSeason, seasonID)
+		}
+		return "", err
+	}
+	return seasonID, nil
+}
+
+type SeasonService struct {
+	seasonRepo *repositories.SeasonRepository
+	matchRepo  *repositories.MatchRepository
+	teamRepo   *repositories.TeamRepository
+}
+
+func NewSeasonService() *SeasonService {
+	return &SeasonService{
+		seasonRepo: repositories.NewSeasonRepository(),
+		matchRepo:  repositories.NewMatchRepository(),
+		teamRepo:   repositories.NewTeamRepository(),
+	}
+}
+
+// GetSeasons lists the archive, most recent season first
+func (s *SeasonService) GetSeasons() ([]models.Season, error) {
+	seasons, err := s.seasonRepo.GetAllSeasons()
+	if err != nil {
+		return nil, err
+	}
+	if seasons == nil {
+		seasons = []models.Season{}
+	}
+	return seasons, nil
+}
+
+// GetSeason returns one season, including its final table once it has been archived
+func (s *SeasonService) GetSeason(id string) (*models.Season, error) {
+	seasonID, err := ResolveSeasonID(id)
+	if err != nil {
+		return nil, err
+	}
+	season, err := s.seasonRepo.GetSeasonByID(seasonID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// The default season before it has been written to the seasons collection
+		return newSeason(seasonID), nil
+	}
+	return season, err
+}
+
+// PromotedClub is a club coming up into the new season. ID may be left empty for a
+// club that is not in the teams collection yet; Name is matched against the team
+// aliases before a new team is created.
+type PromotedClub struct {
+	ID        string `json:"id"`
+	Name      string `json:"name" binding:"required"`
+	ShortName string `json:"shortName"`
+	City      string `json:"city"`
+	Stadium   string `json:"stadium"`
+	LogoURL   string `json:"logoUrl"`
+}
+
+type RolloverRequest struct {
+	// NewSeasonID defaults to the season after the active one, e.g. "2026-27"
+	NewSeasonID string         `json:"newSeasonId"`
+	StartDate   time.Time      `json:"startDate"`
+	Promoted    []PromotedClub `json:"promoted" binding:"required"`
+	// Force rolls over even though some matches of the season were never finished
+	Force bool `json:"force"`
+}
+
+type RolloverResult struct {
+	Archived  *models.Season `json:"archived"`
+	Season    *models.Season `json:"season"`
+	Relegated []models.Team  `json:"relegated"`
+	Promoted  []models.Team  `json:"promoted"`
+}
+
+// Rollover ends the active season: its table is frozen onto the season document, the
+// bottom three are relegated and replaced by the promoted clubs, and a new season with
+// empty standings becomes active. Player statistics start from zero because they are
+// kept per season in player_season_stats; the career totals on each player are untouched.
+func (s *SeasonService) Rollover(req RolloverRequest) (*RolloverResult, error) {
+	currentID, err := ResolveSeasonID("")
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.GetSeason(currentID)
+	if err != nil {
+		return nil, err
+	}
+
+	newID := models.NormalizeSeasonID(req.NewSeasonID)
+	if newID == "" {
+		if newID, err = nextSeasonID(currentID); err != nil {
+			return nil, err
+		}
+	}
+	if newID == currentID {
+		return nil, fmt.Errorf("%w: %s is the active season", ErrInvalidRollover, newID)
+	}
+	if _, err := s.seasonRepo.GetSeasonByID(newID); err == nil {
+		return nil, fmt.Errorf("%w: season %s already exists", ErrInvalidRollover, newID)
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	if !req.Force {
+		matches, err := s.matchRepo.GetMatchesBySeason(currentID)
+		if err != nil {
+			return nil, err
+		}
+		unfinished := 0
+		for _, m := range matches {
+			if m.Status != models.MatchFinished {
+				unfinished++
+			}
+		}
+		if unfinished > 0 {
+			return nil, fmt.Errorf("%w: %d matches of %s are not finished", ErrInvalidRollover, unfinished, currentID)
+		}
+	}
+
+	table, err := NewFootballService().GetStandings(currentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(table) <= RelegationPlaces {
+		return nil, fmt.Errorf("%w: %s has only %d clubs in its table", ErrInvalidRollover, currentID, len(table))
+	}
+	if len(req.Promoted) != RelegationPlaces {
+		return nil, fmt.Errorf("%w: %d clubs are relegated, so %d must be promoted", ErrInvalidRollover, RelegationPlaces, RelegationPlaces)
+	}
+
+	league := make(map[string]bool)
+	for _, id := range current.TeamIDs {
+		league[id] = true
+	}
+	for _, st := range table {
+		league[st.TeamID] = true
+	}
+
+	result := &RolloverResult{}
+	for _, st := range table[len(table)-RelegationPlaces:] {
+		delete(league, st.TeamID)
+		result.Relegated = append(result.Relegated, st.Team)
+	}
+
+	promoted, newTeams, err := s.resolvePromoted(req.Promoted, league)
+	if err != nil {
+		return nil, err
+	}
+	for i := range newTeams {
+		if err := s.teamRepo.CreateTeam(&newTeams[i]); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range promoted {
+		league[t.ID] = true
+	}
+	result.Promoted = promoted
+
+	// Freeze the final table
+	for i := range table {
+		table[i].NextOpponent = ""
+		table[i].NextOpponentLogo = ""
+	}
+	current.FinalTable = table
+	current.Relegated = teamIDs(result.Relegated)
+	current.Promoted = teamIDs(result.Promoted)
+	current.ArchivedAt = time.Now()
+	current.IsActive = false
+	if current.EndDate.IsZero() {
+		current.EndDate = current.ArchivedAt
+	}
+	if err := s.seasonRepo.UpsertSeason(current); err != nil {
+		return nil, err
+	}
+
+	next := newSeason(newID)
+	next.StartDate = req.StartDate
+	for id := range league {
+		next.TeamIDs = append(next.TeamIDs, id)
+	}
+	sort.Strings(next.TeamIDs)
+	if err := s.seasonRepo.UpsertSeason(next); err != nil {
+		return nil, err
+	}
+
+	standings := make([]models.Standing, 0, len(next.TeamIDs))
+	for _, id := range next.TeamIDs {
+		standings = append(standings, models.Standing{
+			ID:       models.SeasonScopedID(newID, id),
+			TeamID:   id,
+			SeasonID: newID,
+			Form:     []string{},
+		})
+	}
+	if err := s.matchRepo.CreateStandings(standings); err != nil {
+		return nil, err
+	}
+
+	if err := s.seasonRepo.SetActiveSeason(newID); err != nil {
+		return nil, err
+	}
+	next.IsActive = true
+
+	result.Archived = current
+	result.Season = next
+	return result, nil
+}
+
+// resolvePromoted finds the team record for each promoted club. Clubs that don't exist
+// yet are also returned in newTeams, with the ID the admin gave or a generated one.
+func (s *SeasonService) resolvePromoted(clubs []PromotedClub, league map[string]bool) (teams, newTeams []models.Team, err error) {
+	resolver, err := LoadTeamResolver(s.teamRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, club := range clubs {
+		if strings.TrimSpace(club.Name) == "" {
+			return nil, nil, fmt.Errorf("%w: promoted clubs need a name", ErrInvalidRollover)
+		}
+
+		var team *models.Team
+		if club.ID != "" {
+			team, err = s.teamRepo.GetTeamByID(club.ID)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, nil, err
+			}
+		} else if t, ok := resolver.Resolve(club.Name); ok {
+			team = t
+		}
+
+		if team == nil {
+			team = &models.Team{
+				ID:        club.ID,
+				Name:      strings.TrimSpace(club.Name),
+				ShortName: club.ShortName,
+				City:      club.City,
+				Stadium:   club.Stadium,
+				LogoURL:   club.LogoURL,
+			}
+			if team.ID == "" {
+				team.ID = primitive.NewObjectID().Hex()
+			}
+			newTeams = append(newTeams, *team)
+		}
+
+		name := NormalizeTeamName(team.Name)
+		if seen[team.ID] || seen[name] {
+			return nil, nil, fmt.Errorf("%w: %s is promoted twice", ErrInvalidRollover, team.Name)
+		}
+		if league[team.ID] {
+			return nil, nil, fmt.Errorf("%w: %s already plays in the league", ErrInvalidRollover, team.Name)
+		}
+		seen[team.ID], seen[name] = true, true
+		teams = append(teams, *team)
+	}
+	return teams, newTeams, nil
+}
+
+func teamIDs(teams []models.Team) []string {
+	ids := make([]string, 0, len(teams))
+	for _, t := range teams {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// newSeason returns an inactive season named after its ID, e.g. "2026-27" is
+// "Premier League 2026/27"
+func newSeason(id string) *models.Season {
+	year := strings.Replace(id, "-", "/", 1)
+	return &models.Season{
+		ID:   id,
+		Name: "Premier League " + year,
+		Year: year,
+	}
+}
+
+// nextSeasonID returns the season after the given one: "2025-26" -> "2026-27"
+func nextSeasonID(id string) (string, error) {
+	var start, end int
+	if _, err := fmt.Sscanf(id, "%d-%d", &start, &end); err != nil {
+		return "", fmt.Errorf("%w: cannot work out the season after %q, pass newSeasonId", ErrInvalidRollover, id)
+	}
+	return fmt.Sprintf("%d-%02d", start+1, (start+2)%100), nil
+}
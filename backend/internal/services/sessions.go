@@ -0,0 +1,147 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token already used, session revoked")
+)
+
+// TokenPair is what signing in, and refreshing, returns
+type TokenPair struct {
+	AccessToken      string    `json:"token"`
+	ExpiresIn        int       `json:"expiresIn"` // Seconds the access token is valid
+	RefreshToken     string    `json:"refreshToken"`
+	RefreshExpiresAt time.Time `json:"refreshExpiresAt"`
+}
+
+// Client describes where a session was started from, for the user's records
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
+// startSession issues the first token pair of a new session
+func (s *AuthService) startSession(user *models.User, client Client) (*TokenPair, error) {
+	pair, _, err := s.issueTokens(user, primitive.NewObjectID().Hex(), client)
+	return pair, err
+}
+
+// issueTokens signs an access token and stores a new refresh token in the given session
+func (s *AuthService) issueTokens(user *models.User, familyID string, client Client) (*TokenPair, primitive.ObjectID, error) {
+	cfg := config.LoadConfig()
+	access, err := utils.GenerateToken(user.ID.Hex(), user.Email, user.Role)
+	if err != nil {
+		return nil, primitive.NilObjectID, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, primitive.NilObjectID, err
+	}
+	refresh := base64.RawURLEncoding.EncodeToString(b)
+
+	ttl := time.Duration(cfg.RefreshTokenTTL) * 24 * time.Hour
+	if ttl <= 0 {
+		ttl = 30 * 24 * time.Hour
+	}
+	now := time.Now()
+	token := &models.RefreshToken{
+		TokenHash: hashToken(refresh),
+		UserID:    user.ID.Hex(),
+		FamilyID:  familyID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+	}
+	if err := s.refreshTokens.CreateRefreshToken(token); err != nil {
+		return nil, primitive.NilObjectID, err
+	}
+
+	return &TokenPair{
+		AccessToken:      access,
+		ExpiresIn:        int(utils.AccessTokenTTL(cfg).Seconds()),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, token.ID, nil
+}
+
+// Refresh exchanges a refresh token for a new pair. Each refresh token works once: a
+// token presented again means it was stolen (or the client has a bug), and the whole
+// session is revoked so neither copy can be used.
+func (s *AuthService) Refresh(refreshToken string, client Client) (*TokenPair, error) {
+	token, err := s.refreshTokens.GetRefreshTokenByHash(hashToken(refreshToken))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if token.RevokedAt != nil || time.Now().After(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		return nil, s.revokeReusedSession(token)
+	}
+
+	// Role and account changes since the last refresh apply to the new access token
+	user, err := s.userRepo.GetUserByID(token.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	pair, next, err := s.issueTokens(user, token.FamilyID, client)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.refreshTokens.UseRefreshToken(token.ID, next)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Another request used the token first
+		return nil, s.revokeReusedSession(token)
+	}
+	return pair, nil
+}
+
+func (s *AuthService) revokeReusedSession(token *models.RefreshToken) error {
+	n, err := s.refreshTokens.RevokeFamily(token.FamilyID)
+	if err != nil {
+		return err
+	}
+	log.Printf("[Auth] Refresh token reuse for user %s: session %s revoked (%d tokens)", token.UserID, token.FamilyID, n)
+	return ErrRefreshTokenReused
+}
+
+// Logout ends the session a refresh token belongs to. An unknown token is not an error.
+func (s *AuthService) Logout(refreshToken string) error {
+	token, err := s.refreshTokens.GetRefreshTokenByHash(hashToken(refreshToken))
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = s.refreshTokens.RevokeFamily(token.FamilyID)
+	return err
+}
+
+// LogoutAll ends every session of a user. Access tokens already issued stay valid until
+// they expire.
+func (s *AuthService) LogoutAll(userID string) (int64, error) {
+	return s.refreshTokens.RevokeUserTokens(userID)
+}
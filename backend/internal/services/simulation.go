@@ -10,6 +10,7 @@ import (
 
 	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
 	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -45,9 +46,9 @@ func SimulateMatch(matchID string) {
 			return
 		}
 
-		// Load home team squad
-		homePlayers := loadTeamPlayers(ctx, match.HomeTeamID)
-		awayPlayers := loadTeamPlayers(ctx, match.AwayTeamID)
+		// Load the players available to each side: the named lineup, or the squad minus suspended players
+		homePlayers := loadMatchSquad(ctx, match.SeasonID, match.HomeTeamID, match.HomeLineup)
+		awayPlayers := loadMatchSquad(ctx, match.SeasonID, match.AwayTeamID, match.AwayLineup)
 
 		if len(homePlayers) == 0 || len(awayPlayers) == 0 {
 			log.Printf("[Simulation] No players found for match %s, skipping simulation", matchID)
@@ -58,21 +59,17 @@ func SimulateMatch(matchID string) {
 		homeTeam := loadTeamName(ctx, match.HomeTeamID)
 		awayTeam := loadTeamName(ctx, match.AwayTeamID)
 
-		// Determine total goals (weighted: avg ~2.5 per match)
-		totalGoals := weightedGoalCount()
-
-		if totalGoals == 0 {
-			log.Printf("[Simulation] 0-0 draw for match %s", matchID)
-			return
-		}
-
-		// Generate goal minutes (sorted)
-		minutes := generateGoalMinutes(totalGoals)
+		// Generate shots for both sides (home sides shoot a little more) and interleave bookings
+		timeline := buildSimTimeline(shotCount(13), shotCount(11))
 
 		homeScore := 0
 		awayScore := 0
+		booked := make(map[string]bool)  // Players on a yellow card
+		sentOff := make(map[string]bool) // Players no longer on the pitch
+		eventRepo := repositories.NewDisciplineRepository()
 
-		for i, minute := range minutes {
+		for _, ev := range timeline {
+			minute := ev.minute
 			select {
 			case <-stopCh:
 				log.Printf("[Simulation] Match %s stopped early at minute %d", matchID, minute)
@@ -80,34 +77,113 @@ func SimulateMatch(matchID string) {
 			default:
 			}
 
-			// Wait a bit between events (1-3 seconds real time)
-			time.Sleep(time.Duration(1000+rand.Intn(2000)) * time.Millisecond)
+			if ev.kind != models.Shot {
+				// Wait a bit between events (1-3 seconds real time)
+				time.Sleep(time.Duration(1000+rand.Intn(2000)) * time.Millisecond)
+
+				// Away sides pick up slightly more cards
+				isHomeCard := rand.Float64() < 0.45
+				teamPlayers, teamID := awayPlayers, match.AwayTeamID
+				if isHomeCard {
+					teamPlayers, teamID = homePlayers, match.HomeTeamID
+				}
+				onPitch := withoutPlayers(teamPlayers, sentOff)
+				if len(onPitch) == 0 {
+					continue
+				}
+				player := pickBooked(onPitch)
+
+				cardType := ev.kind
+				if cardType == models.YellowCard && booked[player.ID] {
+					cardType = models.RedCard // Second booking
+				}
+				booked[player.ID] = true
+				if cardType == models.RedCard {
+					sentOff[player.ID] = true
+				}
+
+				card := &models.MatchEvent{
+					ID:         primitive.NewObjectID().Hex(),
+					MatchID:    matchID,
+					PlayerID:   player.ID,
+					PlayerName: player.Name,
+					TeamID:     teamID,
+					SeasonID:   match.SeasonID,
+					Matchday:   match.Matchday,
+					Type:       cardType,
+					Minute:     minute,
+				}
+				if err := eventRepo.CreateMatchEvent(card); err != nil {
+					log.Printf("[Simulation] Failed to save card: %v", err)
+					continue
+				}
+				log.Printf("[Simulation] Match %s | %d' %s %s", matchID, minute, cardType, player.Name)
+				continue
+			}
 
-			// Decide which team scores (roughly 50/50 with slight home advantage)
-			isHomeGoal := rand.Float64() < 0.55
-			var scorer models.Player
-			var assist *models.Player
+			isHomeGoal := ev.home
 			var teamPlayers []models.Player
 			var teamName, teamID string
-
 			if isHomeGoal {
 				teamPlayers = homePlayers
 				teamName = homeTeam
 				teamID = match.HomeTeamID
-				homeScore++
 			} else {
 				teamPlayers = awayPlayers
 				teamName = awayTeam
 				teamID = match.AwayTeamID
-				awayScore++
 			}
 
-			// Pick scorer based on position probability
-			scorer = pickScorer(teamPlayers)
+			// Sent-off players can't shoot or assist
+			if onPitch := withoutPlayers(teamPlayers, sentOff); len(onPitch) > 0 {
+				teamPlayers = onPitch
+			}
+
+			// Pick the shooter based on position probability; the shot goes in with probability xG
+			shooter := pickScorer(teamPlayers)
+			zone, shotType := randomShot()
+			xg, _ := ExpectedGoals(zone, shotType)
+			scored := rand.Float64() < xg
 
-			// Pick assist (85% chance of having one)
-			if rand.Float64() < 0.85 {
-				a := pickAssist(teamPlayers, scorer.ID)
+			if scored {
+				time.Sleep(time.Duration(1000+rand.Intn(2000)) * time.Millisecond)
+			} else {
+				time.Sleep(time.Duration(200+rand.Intn(400)) * time.Millisecond)
+			}
+
+			shot := &models.MatchEvent{
+				ID:         primitive.NewObjectID().Hex(),
+				MatchID:    matchID,
+				PlayerID:   shooter.ID,
+				PlayerName: shooter.Name,
+				TeamID:     teamID,
+				SeasonID:   match.SeasonID,
+				Matchday:   match.Matchday,
+				Type:       models.Shot,
+				Minute:     minute,
+				ShotZone:   zone,
+				ShotType:   shotType,
+				XG:         xg,
+				IsGoal:     scored,
+			}
+			if err := eventRepo.CreateMatchEvent(shot); err != nil {
+				log.Printf("[Simulation] Failed to save shot: %v", err)
+				continue
+			}
+			if !scored {
+				continue
+			}
+
+			if isHomeGoal {
+				homeScore++
+			} else {
+				awayScore++
+			}
+
+			// Pick assist (85% chance of having one, never for penalties or free kicks)
+			var assist *models.Player
+			if shotType != models.ShotPenalty && shotType != models.ShotFreeKick && rand.Float64() < 0.85 {
+				a := pickAssist(teamPlayers, shooter.ID)
 				assist = &a
 			}
 
@@ -119,12 +195,13 @@ func SimulateMatch(matchID string) {
 				Matchday:   match.Matchday,
 				HomeTeam:   homeTeam,
 				AwayTeam:   awayTeam,
-				ScorerID:   scorer.ID,
-				ScorerName: scorer.Name,
+				ScorerID:   shooter.ID,
+				ScorerName: shooter.Name,
 				TeamName:   teamName,
 				TeamID:     teamID,
 				Minute:     minute,
 				IsHomeGoal: isHomeGoal,
+				SeasonID:   match.SeasonID,
 			}
 
 			if assist != nil {
@@ -145,10 +222,8 @@ func SimulateMatch(matchID string) {
 				bson.M{"$set": bson.M{"homeScore": homeScore, "awayScore": awayScore}},
 			)
 
-			log.Printf("[Simulation] Match %s | %d' GOAL! %s (%s) %d-%d",
-				matchID, minute, scorer.Name, teamName, homeScore, awayScore)
-
-			_ = i
+			log.Printf("[Simulation] Match %s | %d' GOAL! %s (%s, %.2f xG) %d-%d",
+				matchID, minute, shooter.Name, teamName, xg, homeScore, awayScore)
 		}
 
 		log.Printf("[Simulation] Match %s simulation complete: %s %d - %d %s",
@@ -162,15 +237,20 @@ func SimulateMatch(matchID string) {
 			hScore, aScore = homeScore, awayScore // Fallback
 		}
 
-		// 2. Update Match Status to FINISHED in DB
-		_, err = database.DB.Collection("matches").UpdateOne(ctx,
-			bson.M{"_id": matchID},
-			bson.M{"$set": bson.M{"status": "FINISHED", "homeScore": hScore, "awayScore": aScore}},
+		// 2. Update Match Status to FINISHED in DB, unless it was abandoned, cancelled or
+		// finished by hand during the last minutes; then the rest is not ours to do
+		res, err := database.DB.Collection("matches").UpdateOne(ctx,
+			bson.M{"_id": matchID, "status": models.MatchLive},
+			bson.M{"$set": bson.M{"status": models.MatchFinished, "homeScore": hScore, "awayScore": aScore}},
 		)
 		if err != nil {
 			log.Printf("[Simulation] Failed to update match status to FINISHED: %v", err)
 			return
 		}
+		if res.ModifiedCount == 0 {
+			log.Printf("[Simulation] Match %s is no longer LIVE, leaving it as it is", matchID)
+			return
+		}
 
 		// 3. Update Standings
 		// We need to pass a Match object with FINISHED status to UpdateStandings
@@ -188,6 +268,11 @@ func SimulateMatch(matchID string) {
 		if err := UpdatePlayerStatsForMatch(ctx, matchID); err != nil {
 			log.Printf("[Simulation] Error updating player stats for match %s: %v", matchID, err)
 		}
+
+		// 5. Serve and issue suspensions
+		if err := ProcessMatchDiscipline(ctx, matchID); err != nil {
+			log.Printf("[Simulation] Error processing discipline for match %s: %v", matchID, err)
+		}
 	}()
 }
 
@@ -220,6 +305,39 @@ func loadTeamPlayers(ctx context.Context, teamID string) []models.Player {
 	return players
 }
 
+// loadMatchSquad returns the players a team can use in a match: its lineup if one was
+// named, otherwise the whole squad without suspended players
+func loadMatchSquad(ctx context.Context, seasonID, teamID string, lineup []string) []models.Player {
+	if len(lineup) > 0 {
+		cursor, err := database.DB.Collection("players").Find(ctx, bson.M{"_id": bson.M{"$in": lineup}})
+		if err != nil {
+			return nil
+		}
+		var players []models.Player
+		cursor.All(ctx, &players)
+		return players
+	}
+
+	players := loadTeamPlayers(ctx, teamID)
+	suspended, err := SuspendedPlayerIDs(seasonID, teamID)
+	if err != nil {
+		log.Printf("[Simulation] Failed to load suspensions for team %s: %v", teamID, err)
+		return players
+	}
+	return withoutPlayers(players, suspended)
+}
+
+// withoutPlayers filters out the players whose IDs are in the exclude set
+func withoutPlayers(players []models.Player, exclude map[string]bool) []models.Player {
+	var result []models.Player
+	for _, p := range players {
+		if !exclude[p.ID] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
 // loadTeamName returns the team name
 func loadTeamName(ctx context.Context, teamID string) string {
 	var team models.Team
@@ -230,38 +348,56 @@ func loadTeamName(ctx context.Context, teamID string) string {
 	return team.Name
 }
 
-// weightedGoalCount returns a realistic total goal count
-func weightedGoalCount() int {
-	// Distribution: 0 goals=8%, 1=18%, 2=25%, 3=22%, 4=14%, 5=8%, 6=5%
+// simEvent is a scheduled moment in a simulated match
+type simEvent struct {
+	minute int
+	kind   models.EventType
+	home   bool // Side taking the shot
+}
+
+// buildSimTimeline spreads shots and bookings over the 90 minutes, in minute order
+func buildSimTimeline(homeShots, awayShots int) []simEvent {
+	var timeline []simEvent
+	for i := 0; i < homeShots; i++ {
+		timeline = append(timeline, simEvent{minute: 1 + rand.Intn(90), kind: models.Shot, home: true})
+	}
+	for i := 0; i < awayShots; i++ {
+		timeline = append(timeline, simEvent{minute: 1 + rand.Intn(90), kind: models.Shot})
+	}
+	for i := 0; i < weightedCardCount(); i++ {
+		timeline = append(timeline, simEvent{minute: 1 + rand.Intn(90), kind: models.YellowCard})
+	}
+	// Straight reds are rare
+	if rand.Float64() < 0.06 {
+		timeline = append(timeline, simEvent{minute: 1 + rand.Intn(90), kind: models.RedCard})
+	}
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].minute < timeline[j].minute })
+	return timeline
+}
+
+// weightedCardCount returns a realistic number of yellow cards for a match (avg ~3.5)
+func weightedCardCount() int {
 	r := rand.Float64()
 	switch {
-	case r < 0.08:
+	case r < 0.05:
 		return 0
-	case r < 0.26:
+	case r < 0.15:
 		return 1
-	case r < 0.51:
+	case r < 0.32:
 		return 2
-	case r < 0.73:
+	case r < 0.55:
 		return 3
-	case r < 0.87:
+	case r < 0.75:
 		return 4
-	case r < 0.95:
+	case r < 0.88:
 		return 5
-	default:
+	case r < 0.95:
 		return 6
+	default:
+		return 7
 	}
 }
 
-// generateGoalMinutes generates sorted random minutes for goals
-func generateGoalMinutes(count int) []int {
-	mins := make([]int, count)
-	for i := range mins {
-		mins[i] = 1 + rand.Intn(90)
-	}
-	sort.Ints(mins)
-	return mins
-}
-
 // weightedPlayer is used for position-based probability selection
 type weightedPlayer struct {
 	player models.Player
@@ -317,6 +453,27 @@ func pickAssist(players []models.Player, scorerID string) models.Player {
 	return weightedPick(candidates)
 }
 
+// pickBooked selects a player to be booked, defenders and midfielders most often
+func pickBooked(players []models.Player) models.Player {
+	var candidates []weightedPlayer
+	for _, p := range players {
+		w := 1.0
+		switch p.Position {
+		case "Defender":
+			w = 3.0
+		case "Midfielder":
+			w = 3.0
+		case "Attacker", "Forward":
+			w = 1.5
+		case "Goalkeeper":
+			w = 0.3
+		}
+		candidates = append(candidates, weightedPlayer{p, w})
+	}
+
+	return weightedPick(candidates)
+}
+
 // weightedPick does weighted random selection
 func weightedPick(candidates []weightedPlayer) models.Player {
 	totalWeight := 0.0
@@ -359,13 +516,20 @@ func RecalculateMatchScore(ctx context.Context, matchID string) (int, int, error
 	return h, a, err
 }
 
-// RecalculateAllStandings drops standings and replays all finished matches
-func RecalculateAllStandings(ctx context.Context) error {
-	// Drop existing standings
-	database.DB.Collection("standings").Drop(ctx)
+// RecalculateSeasonStandings drops a season's standings and replays its finished matches
+func RecalculateSeasonStandings(ctx context.Context, seasonID string) error {
+	seasonID, err := ResolveSeasonID(seasonID)
+	if err != nil {
+		return err
+	}
+
+	// Drop existing standings for the season
+	if _, err := database.DB.Collection("standings").DeleteMany(ctx, bson.M{"seasonId": seasonID}); err != nil {
+		return err
+	}
 
-	// Get all finished matches
-	cursor, err := database.DB.Collection("matches").Find(ctx, bson.M{"status": "FINISHED"})
+	// Get the season's finished matches
+	cursor, err := database.DB.Collection("matches").Find(ctx, bson.M{"status": "FINISHED", "seasonId": seasonID})
 	if err != nil {
 		return err
 	}
@@ -390,6 +554,6 @@ func RecalculateAllStandings(ctx context.Context) error {
 		}
 	}
 
-	log.Printf("[Recalculate] Standings recalculated from %d finished matches", len(matches))
+	log.Printf("[Recalculate] %s standings recalculated from %d finished matches", seasonID, len(matches))
 	return nil
 }
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/config"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/sportmonks"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NewSportMonksClient builds a SportMonks client from the SPORTMONKS_* settings
+func NewSportMonksClient(cfg *config.Config) (*sportmonks.Client, error) {
+	return sportmonks.NewClient(sportmonks.Options{
+		Token:             cfg.SportMonksToken,
+		BaseURL:           cfg.SportMonksBaseURL,
+		Mode:              sportmonks.Mode(cfg.SportMonksMode),
+		FixturesDir:       cfg.SportMonksFixturesDir,
+		RequestsPerMinute: cfg.SportMonksRateLimit,
+	})
+}
+
+// ImportResult counts what an import did
+type ImportResult struct {
+	Inserted   int `json:"inserted"`
+	Updated    int `json:"updated"`
+	Skipped    int `json:"skipped"`
+	WouldWrite int `json:"wouldWrite,omitempty"` // Writes a dry run left out
+}
+
+func (r ImportResult) String() string {
+	if r.WouldWrite > 0 {
+		return fmt.Sprintf("%d would be written, %d skipped", r.WouldWrite, r.Skipped)
+	}
+	return fmt.Sprintf("%d inserted, %d updated, %d skipped", r.Inserted, r.Updated, r.Skipped)
+}
+
+func (r *ImportResult) add(o ImportResult) {
+	r.Inserted += o.Inserted
+	r.Updated += o.Updated
+	r.Skipped += o.Skipped
+	r.WouldWrite += o.WouldWrite
+}
+
+// SportMonksImporter writes SportMonks squads, standings and fixtures into our collections.
+// SportMonks teams are matched by their "sportmonks" external ID, then by our own team ID
+// (the teams were first seeded from SportMonks), then by name.
+type SportMonksImporter struct {
+	DryRun bool // Fetch and match everything, but write nothing
+
+	client   *sportmonks.Client
+	teams    []models.Team
+	byID     map[string]string // SportMonks team ID -> our team ID
+	resolver *TeamResolver
+}
+
+func NewSportMonksImporter(client *sportmonks.Client, teams []models.Team) *SportMonksImporter {
+	i := &SportMonksImporter{
+		client:   client,
+		teams:    teams,
+		byID:     make(map[string]string),
+		resolver: NewTeamResolver(teams),
+	}
+	for _, t := range teams {
+		i.byID[t.ID] = t.ID
+	}
+	for _, t := range teams {
+		if id, ok := t.ExternalIDs["sportmonks"]; ok {
+			i.byID[id] = t.ID
+		}
+	}
+	return i
+}
+
+// Resolver returns the resolver used for name lookups, to report unmatched names
+func (i *SportMonksImporter) Resolver() *TeamResolver {
+	return i.resolver
+}
+
+// teamID returns our ID for a SportMonks team
+func (i *SportMonksImporter) teamID(p sportmonks.Participant) (string, bool) {
+	if id, ok := i.byID[strconv.Itoa(p.ID)]; ok {
+		return id, true
+	}
+	return i.resolver.ResolveID(p.Name)
+}
+
+// sportMonksID returns the SportMonks ID of one of our teams
+func sportMonksID(team models.Team) string {
+	if id, ok := team.ExternalIDs["sportmonks"]; ok {
+		return id
+	}
+	return team.ID
+}
+
+// ImportSquads fetches the squad of every team and saves its players
+func (i *SportMonksImporter) ImportSquads(ctx context.Context, smSeasonID string) (ImportResult, error) {
+	var total ImportResult
+	for _, team := range i.teams {
+		squad, err := i.client.Squad(ctx, sportMonksID(team), smSeasonID)
+		if errors.Is(err, sportmonks.ErrNoFixture) {
+			// Replaying a recording that only has some of the squads
+			log.Printf("[SportMonks] %s: %v", team.Name, err)
+			total.Skipped++
+			continue
+		}
+		if err != nil {
+			return total, fmt.Errorf("squad of %s: %w", team.Name, err)
+		}
+		res, err := i.SaveSquad(ctx, team.ID, squad)
+		if err != nil {
+			return total, fmt.Errorf("save squad of %s: %w", team.Name, err)
+		}
+		log.Printf("[SportMonks] %s: %d players (%s)", team.Name, len(squad), res)
+		total.add(res)
+	}
+	return total, nil
+}
+
+// SaveSquad upserts the players of a SportMonks squad into a team. Statistics are
+// only initialised for new players, so re-importing a squad keeps the numbers.
+func (i *SportMonksImporter) SaveSquad(ctx context.Context, teamID string, squad []sportmonks.SquadEntry) (ImportResult, error) {
+	var res ImportResult
+	coll := database.DB.Collection("players")
+	for _, item := range squad {
+		p := item.Player
+		if p.ID == 0 {
+			res.Skipped++
+			continue
+		}
+		pos := p.Position.Name
+		if pos == "" {
+			pos = "Unknown"
+		}
+
+		update := bson.M{
+			"$set": bson.M{
+				"teamId":           teamID,
+				"name":             p.Name,
+				"commonName":       p.CommonName,
+				"firstName":        p.FirstName,
+				"lastName":         p.LastName,
+				"displayName":      p.DisplayName,
+				"position":         pos,
+				"detailedPosition": pos,
+				"nationality":      p.Nationality.Name,
+				"nationalityCode":  p.Nationality.ISO3,
+				"nationalityISO2":  p.Nationality.ISO2,
+				"number":           item.JerseyNumber,
+				"height":           int(p.Height),
+				"weight":           int(p.Weight),
+				"dateOfBirth":      p.DateOfBirth,
+				"imagePath":        p.ImagePath,
+				"isCaptain":        item.Captain,
+			},
+			"$setOnInsert": bson.M{"statistics": models.PlayerStats{}},
+		}
+		if err := i.upsert(ctx, coll, strconv.Itoa(p.ID), update, &res); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// ImportStandings fetches a season's table and saves it as the standings of seasonID
+func (i *SportMonksImporter) ImportStandings(ctx context.Context, smSeasonID, seasonID string) (ImportResult, error) {
+	standings, err := i.client.Standings(ctx, smSeasonID)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return i.SaveStandings(ctx, seasonID, standings)
+}
+
+// SaveStandings upserts each club of a SportMonks table and its standing in seasonID.
+// A club we don't know yet is created under its SportMonks ID.
+func (i *SportMonksImporter) SaveStandings(ctx context.Context, seasonID string, standings []sportmonks.Standing) (ImportResult, error) {
+	var res ImportResult
+	teamColl := database.DB.Collection("teams")
+	standingColl := database.DB.Collection("standings")
+
+	for _, s := range standings {
+		smID := strconv.Itoa(s.Participant.ID)
+		teamID, ok := i.teamID(s.Participant)
+		if !ok {
+			teamID = smID
+			i.byID[smID] = teamID
+		}
+
+		// Only the fields SportMonks knows about; city, stadium, coach and aliases are ours
+		var teamRes ImportResult
+		err := i.upsert(ctx, teamColl, teamID, bson.M{"$set": bson.M{
+			"name":                   s.Participant.Name,
+			"shortName":              s.Participant.ShortCode,
+			"logoUrl":                s.Participant.ImagePath,
+			"externalIds.sportmonks": smID,
+		}}, &teamRes)
+		if err != nil {
+			return res, fmt.Errorf("save team %s: %w", s.Participant.Name, err)
+		}
+
+		id := models.SeasonScopedID(seasonID, teamID)
+		standing := bson.M{
+			"teamId":         teamID,
+			"seasonId":       seasonID,
+			"position":       s.Position,
+			"played":         s.Detail(sportmonks.TypePlayed),
+			"wins":           s.Detail(sportmonks.TypeWon),
+			"draws":          s.Detail(sportmonks.TypeDraw),
+			"losses":         s.Detail(sportmonks.TypeLost),
+			"points":         s.Points,
+			"goalsFor":       s.Detail(sportmonks.TypeGoalsFor),
+			"goalsAgainst":   s.Detail(sportmonks.TypeGoalsAgainst),
+			"goalDifference": s.Detail(sportmonks.TypeGoalDiff),
+		}
+		if err := i.upsert(ctx, standingColl, id, bson.M{"$set": standing}, &res); err != nil {
+			return res, fmt.Errorf("save standing of %s: %w", s.Participant.Name, err)
+		}
+	}
+	return res, nil
+}
+
+// ImportFixtures fetches every fixture of a season and upserts it into the matches of
+// seasonID, with the same IDs the openfootball import uses. As there, fixture details
+// are always refreshed but results only overwrite a match once SportMonks has one, so
+// matches that are live or simulated locally keep their state.
+func (i *SportMonksImporter) ImportFixtures(ctx context.Context, smSeasonID, seasonID string) (ImportResult, error) {
+	var res ImportResult
+	fixtures, err := i.client.Fixtures(ctx, smSeasonID)
+	if err != nil {
+		return res, err
+	}
+
+	coll := database.DB.Collection("matches")
+	for _, f := range fixtures {
+		home, ok1 := f.Team("home")
+		away, ok2 := f.Team("away")
+		homeID, ok3 := i.teamID(home)
+		awayID, ok4 := i.teamID(away)
+		date, err := f.Kickoff()
+		if !ok1 || !ok2 || !ok3 || !ok4 || err != nil || f.Matchday() == 0 {
+			log.Printf("[SportMonks] Skipping fixture %d (%s)", f.ID, f.Name)
+			res.Skipped++
+			continue
+		}
+
+		id := fmt.Sprintf("M%d_%s_%s", f.Matchday(), homeID, awayID)
+		set := bson.M{
+			"homeTeamId": homeID,
+			"awayTeamId": awayID,
+			"matchday":   f.Matchday(),
+			"date":       date,
+			"seasonId":   seasonID,
+		}
+		setOnInsert := bson.M{"status": models.MatchScheduled, "homeScore": 0, "awayScore": 0}
+
+		switch {
+		case f.Finished():
+			set["status"] = models.MatchFinished
+			set["homeScore"] = f.Goals("home")
+			set["awayScore"] = f.Goals("away")
+			setOnInsert = bson.M{}
+		case f.State.DeveloperName == sportmonks.StatePostponed,
+			f.State.DeveloperName == sportmonks.StateCancelled,
+			f.State.DeveloperName == sportmonks.StateAbandoned:
+			// Don't move a match that has already been rescheduled here
+			delete(set, "date")
+			setOnInsert["date"] = date
+			setOnInsert["status"] = models.MatchStatus(f.State.DeveloperName)
+		}
+
+		update := bson.M{"$set": set}
+		if len(setOnInsert) > 0 {
+			update["$setOnInsert"] = setOnInsert
+		}
+		if err := i.upsert(ctx, coll, id, update, &res); err != nil {
+			return res, fmt.Errorf("upsert %s: %w", id, err)
+		}
+	}
+	return res, nil
+}
+
+// upsert applies update to the document with the given _id, creating it if needed,
+// and counts the outcome; a dry run only counts
+func (i *SportMonksImporter) upsert(ctx context.Context, coll *mongo.Collection, id string, update bson.M, res *ImportResult) error {
+	if i.DryRun {
+		res.WouldWrite++
+		return nil
+	}
+	r, err := coll.UpdateOne(ctx, bson.M{"_id": id}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	if r.UpsertedCount > 0 {
+		res.Inserted++
+	} else if r.ModifiedCount > 0 {
+		res.Updated++
+	}
+	return nil
+}
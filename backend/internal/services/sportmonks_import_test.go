@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/database"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/sportmonks"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// recordingsDir holds the SportMonks responses the repository ships
+const recordingsDir = "../../fixtures/sportmonks"
+
+// dryRunImporter returns an importer that replays recordings from dir and writes
+// nothing. Dry runs never reach the database, so it only needs a handle that isn't
+// connected to anything.
+func dryRunImporter(t *testing.T, dir string, teams []models.Team) *SportMonksImporter {
+	t.Helper()
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatalf("mongo.Connect: %v", err)
+	}
+	saved := database.DB
+	database.DB = client.Database("epl_test")
+	t.Cleanup(func() {
+		database.DB = saved
+		_ = client.Disconnect(context.Background())
+	})
+
+	sm, err := sportmonks.NewClient(sportmonks.Options{Mode: sportmonks.ModeReplay, FixturesDir: dir})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	importer := NewSportMonksImporter(sm, teams)
+	importer.DryRun = true
+	return importer
+}
+
+func TestReplayImportStandings(t *testing.T) {
+	teams := []models.Team{
+		{ID: "9", Name: "Manchester City"},
+		{ID: "ars", Name: "Arsenal FC", ExternalIDs: map[string]string{"sportmonks": "19"}},
+	}
+	importer := dryRunImporter(t, recordingsDir, teams)
+
+	res, err := importer.ImportStandings(context.Background(), "25583", "2025-26")
+	if err != nil {
+		t.Fatalf("ImportStandings: %v", err)
+	}
+	if res.WouldWrite != 20 || res.Inserted+res.Updated+res.Skipped != 0 {
+		t.Errorf("got %+v, want 20 standings to write", res)
+	}
+
+	// Known clubs keep our IDs; the others are created under their SportMonks IDs
+	for smID, want := range map[int]string{19: "ars", 9: "9", 14: "14"} {
+		if got, ok := importer.teamID(sportmonks.Participant{ID: smID}); !ok || got != want {
+			t.Errorf("SportMonks team %d maps to %q, want %q", smID, got, want)
+		}
+	}
+}
+
+func TestReplayImportSquads(t *testing.T) {
+	teams := []models.Team{
+		{ID: "9", Name: "Manchester City"},
+		{ID: "19", Name: "Arsenal"}, // Not recorded
+	}
+	importer := dryRunImporter(t, recordingsDir, teams)
+
+	res, err := importer.ImportSquads(context.Background(), "25583")
+	if err != nil {
+		t.Fatalf("ImportSquads: %v", err)
+	}
+	if res.WouldWrite != 27 || res.Skipped != 1 {
+		t.Errorf("got %+v, want 27 players to write and 1 squad skipped", res)
+	}
+}
+
+// fixturesRecording is one page of /fixtures: a result, a scheduled match, a postponed
+// match, a club nobody knows and a round that isn't a matchday
+const fixturesRecording = `{"data": [
+  {"id": 1, "name": "Arsenal vs Chelsea", "starting_at": "2025-08-16 14:00:00",
+   "participants": [{"id": 19, "name": "Arsenal", "meta": {"location": "home"}}, {"id": 18, "name": "Chelsea", "meta": {"location": "away"}}],
+   "scores": [{"description": "CURRENT", "score": {"goals": 2, "participant": "home"}}, {"description": "CURRENT", "score": {"goals": 1, "participant": "away"}}],
+   "state": {"developer_name": "FT"}, "round": {"name": "1"}},
+  {"id": 2, "name": "Chelsea vs Arsenal", "starting_at": "2026-01-10 15:00:00",
+   "participants": [{"id": 18, "name": "Chelsea", "meta": {"location": "home"}}, {"id": 19, "name": "Arsenal", "meta": {"location": "away"}}],
+   "state": {"developer_name": "NS"}, "round": {"name": "21"}},
+  {"id": 3, "name": "Arsenal vs Chelsea", "starting_at": "2025-12-01 20:00:00",
+   "participants": [{"id": 19, "name": "Arsenal", "meta": {"location": "home"}}, {"id": 18, "name": "Chelsea FC", "meta": {"location": "away"}}],
+   "state": {"developer_name": "POSTPONED"}, "round": {"name": "14"}},
+  {"id": 4, "name": "Arsenal vs Unknown", "starting_at": "2025-09-01 20:00:00",
+   "participants": [{"id": 19, "name": "Arsenal", "meta": {"location": "home"}}, {"id": 999, "name": "Unknown Town", "meta": {"location": "away"}}],
+   "state": {"developer_name": "NS"}, "round": {"name": "3"}},
+  {"id": 5, "name": "Arsenal vs Chelsea", "starting_at": "2025-09-01 20:00:00",
+   "participants": [{"id": 19, "name": "Arsenal", "meta": {"location": "home"}}, {"id": 18, "name": "Chelsea", "meta": {"location": "away"}}],
+   "state": {"developer_name": "NS"}, "round": {"name": "Quarter-finals"}}
+]}`
+
+func TestReplayImportFixtures(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "fixtures__filters=fixtureSeasons_25583&include=participants;scores;state;round.json")
+	if err := os.WriteFile(file, []byte(fixturesRecording), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	teams := []models.Team{
+		{ID: "ars", Name: "Arsenal", ExternalIDs: map[string]string{"sportmonks": "19"}},
+		{ID: "che", Name: "Chelsea"}, // Matched by name
+	}
+	importer := dryRunImporter(t, dir, teams)
+
+	res, err := importer.ImportFixtures(context.Background(), "25583", "2025-26")
+	if err != nil {
+		t.Fatalf("ImportFixtures: %v", err)
+	}
+	if res.WouldWrite != 3 || res.Skipped != 2 {
+		t.Errorf("got %+v, want 3 fixtures to write and 2 skipped", res)
+	}
+}
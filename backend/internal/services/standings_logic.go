@@ -25,16 +25,27 @@ func UpdateStandings(ctx context.Context, match *models.Match) error {
 		{match.AwayTeamID, match.AwayScore, match.HomeScore},
 	}
 
+	seasonID := match.SeasonID
+	if seasonID == "" {
+		var err error
+		if seasonID, err = ResolveSeasonID(""); err != nil {
+			return err
+		}
+	}
+
 	coll := database.DB.Collection("standings")
 
 	for _, t := range teams {
 		var standing models.Standing
-		filter := bson.M{"_id": t.ID}
+		id := models.SeasonScopedID(seasonID, t.ID)
+		filter := bson.M{"_id": id}
 
 		err := coll.FindOne(ctx, filter).Decode(&standing)
 		if err != nil {
 			// If not found, a new standing object will be initialized with zeroes
+			standing.ID = id
 			standing.TeamID = t.ID
+			standing.SeasonID = seasonID
 		}
 
 		standing.Played++
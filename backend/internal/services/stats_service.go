@@ -7,11 +7,15 @@ import (
 
 type StatsService struct {
 	matchRepo *repositories.MatchRepository
+	teamRepo  *repositories.TeamRepository
+	eventRepo *repositories.DisciplineRepository
 }
 
-func NewStatsService(matchRepo *repositories.MatchRepository) *StatsService {
+func NewStatsService(matchRepo *repositories.MatchRepository, teamRepo *repositories.TeamRepository) *StatsService {
 	return &StatsService{
 		matchRepo: matchRepo,
+		teamRepo:  teamRepo,
+		eventRepo: repositories.NewDisciplineRepository(),
 	}
 }
 
@@ -21,16 +25,21 @@ type StatsResponse struct {
 	CleanSheets []repositories.StatEntry `json:"cleanSheets"`
 }
 
-func (s *StatsService) GetStats() (*StatsResponse, error) {
-	topScorers, err := s.matchRepo.GetTopScorers(10)
+// GetStats returns the leaderboards of a season; an empty season means the active one
+func (s *StatsService) GetStats(season string) (*StatsResponse, error) {
+	seasonID, err := ResolveSeasonID(season)
 	if err != nil {
 		return nil, err
 	}
-	topAssists, err := s.matchRepo.GetTopAssists(10)
+	topScorers, err := s.matchRepo.GetTopScorers(seasonID, 10)
 	if err != nil {
 		return nil, err
 	}
-	cleanSheets, err := s.matchRepo.GetCleanSheets(10)
+	topAssists, err := s.matchRepo.GetTopAssists(seasonID, 10)
+	if err != nil {
+		return nil, err
+	}
+	cleanSheets, err := s.matchRepo.GetCleanSheets(seasonID, 10)
 	if err != nil {
 		return nil, err
 	}
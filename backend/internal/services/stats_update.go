@@ -8,11 +8,25 @@ import (
 	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // UpdatePlayerStatsForMatch updates individual player statistics (Goals, Assists, Clean Sheets)
-// based on the events of a finished match.
+// based on the events of a finished match. Both the career totals on the player and the
+// player's player_season_stats document for the match's season are incremented.
 func UpdatePlayerStatsForMatch(ctx context.Context, matchID string) error {
+	var match models.Match
+	if err := database.DB.Collection("matches").FindOne(ctx, bson.M{"_id": matchID}).Decode(&match); err != nil {
+		return err
+	}
+	seasonID := match.SeasonID
+	if seasonID == "" {
+		var err error
+		if seasonID, err = ResolveSeasonID(""); err != nil {
+			return err
+		}
+	}
+
 	// 1. Get Goal Events
 	cursor, err := database.DB.Collection("goal_events").Find(ctx, bson.M{"matchId": matchID})
 	if err != nil {
@@ -28,13 +42,16 @@ func UpdatePlayerStatsForMatch(ctx context.Context, matchID string) error {
 	// 2. Identify Scorers and Assisters
 	scorers := make(map[string]int)
 	assisters := make(map[string]int)
+	playerTeams := make(map[string]string)
 
 	for _, e := range events {
 		if e.ScorerID != "" {
 			scorers[e.ScorerID]++
+			playerTeams[e.ScorerID] = e.TeamID
 		}
 		if e.AssistID != "" {
 			assisters[e.AssistID]++
+			playerTeams[e.AssistID] = e.TeamID
 		}
 	}
 
@@ -45,6 +62,7 @@ func UpdatePlayerStatsForMatch(ctx context.Context, matchID string) error {
 		if err != nil {
 			log.Printf("[Stats] Failed to update goals for player %s: %v", pID, err)
 		}
+		incSeasonStat(ctx, seasonID, pID, playerTeams[pID], "goals", count)
 	}
 
 	// 4. Update Assisters
@@ -53,30 +71,26 @@ func UpdatePlayerStatsForMatch(ctx context.Context, matchID string) error {
 		if err != nil {
 			log.Printf("[Stats] Failed to update assists for player %s: %v", pID, err)
 		}
+		incSeasonStat(ctx, seasonID, pID, playerTeams[pID], "assists", count)
 	}
 
 	// 5. Clean Sheets
 	// We need to know which teams kept a clean sheet
-	var match models.Match
-	if err := database.DB.Collection("matches").FindOne(ctx, bson.M{"_id": matchID}).Decode(&match); err != nil {
-		return err
-	}
-
 	homeCleanSheet := match.AwayScore == 0
 	awayCleanSheet := match.HomeScore == 0
 
 	if homeCleanSheet {
-		updateCleanSheetsForTeam(ctx, match.HomeTeamID, playerColl)
+		updateCleanSheetsForTeam(ctx, seasonID, match.HomeTeamID, playerColl)
 	}
 	if awayCleanSheet {
-		updateCleanSheetsForTeam(ctx, match.AwayTeamID, playerColl)
+		updateCleanSheetsForTeam(ctx, seasonID, match.AwayTeamID, playerColl)
 	}
 
 	log.Printf("[Stats] Player statistics updated for match %s", matchID)
 	return nil
 }
 
-func updateCleanSheetsForTeam(ctx context.Context, teamID string, playerColl *mongo.Collection) {
+func updateCleanSheetsForTeam(ctx context.Context, seasonID, teamID string, playerColl *mongo.Collection) {
 	// Regex for "Goalkeeper" case-insensitive
 	filter := bson.M{
 		"teamId":   teamID,
@@ -99,5 +113,23 @@ func updateCleanSheetsForTeam(ctx context.Context, teamID string, playerColl *mo
 		} else {
 			log.Printf("[Stats] Clean sheet credited to GK %s (Team %s)", gk.Name, teamID)
 		}
+		incSeasonStat(ctx, seasonID, gk.ID, teamID, "cleanSheets", 1)
+	}
+}
+
+// incSeasonStat adds to one field of a player's statistics for a season,
+// creating the player_season_stats document on first use
+func incSeasonStat(ctx context.Context, seasonID, playerID, teamID, field string, n int) {
+	_, err := database.DB.Collection("player_season_stats").UpdateOne(ctx,
+		bson.M{"_id": models.SeasonScopedID(seasonID, playerID)},
+		bson.M{
+			"$inc":         bson.M{field: n},
+			"$set":         bson.M{"teamId": teamID},
+			"$setOnInsert": bson.M{"seasonId": seasonID, "playerId": playerID},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		log.Printf("[Stats] Failed to update %s %s for player %s: %v", seasonID, field, playerID, err)
 	}
 }
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"github.com/Sanat-07/English-Premier-League/backend/internal/repositories"
+)
+
+// NormalizeTeamName reduces a team name to the key used for alias lookups:
+// "Brighton & Hove Albion FC" -> "brighton and hove albion", "AFC Bournemouth" -> "bournemouth"
+func NormalizeTeamName(name string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, "&", " and "))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	kept := words[:0]
+	for _, w := range words {
+		if w == "fc" || w == "afc" {
+			continue
+		}
+		kept = append(kept, w)
+	}
+	return strings.Join(kept, " ")
+}
+
+// UnmatchedName is a team name that could not be resolved, with how often it was seen
+type UnmatchedName struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TeamResolver maps the team names and IDs used by external sources to our teams.
+// It matches on the official name, short name and the aliases stored on each team,
+// and remembers every name it could not resolve.
+type TeamResolver struct {
+	byKey      map[string]*models.Team
+	ambiguous  map[string]bool
+	byExternal map[string]*models.Team // "source:id" -> team
+
+	mu        sync.Mutex
+	unmatched map[string]int
+}
+
+func NewTeamResolver(teams []models.Team) *TeamResolver {
+	r := &TeamResolver{
+		byKey:      make(map[string]*models.Team),
+		ambiguous:  make(map[string]bool),
+		byExternal: make(map[string]*models.Team),
+		unmatched:  make(map[string]int),
+	}
+	for i := range teams {
+		t := &teams[i]
+		r.add(t.Name, t)
+		r.add(t.ShortName, t)
+		for _, alias := range t.Aliases {
+			r.add(alias, t)
+		}
+		for source, id := range t.ExternalIDs {
+			r.byExternal[source+":"+id] = t
+		}
+	}
+	return r
+}
+
+// LoadTeamResolver builds a resolver from the teams currently in the database
+func LoadTeamResolver(teamRepo *repositories.TeamRepository) (*TeamResolver, error) {
+	teams, err := teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	return NewTeamResolver(teams), nil
+}
+
+// add indexes a name; a name claimed by two different teams resolves to neither
+func (r *TeamResolver) add(name string, team *models.Team) {
+	key := NormalizeTeamName(name)
+	if key == "" || r.ambiguous[key] {
+		return
+	}
+	if existing, ok := r.byKey[key]; ok && existing.ID != team.ID {
+		delete(r.byKey, key)
+		r.ambiguous[key] = true
+		return
+	}
+	r.byKey[key] = team
+}
+
+// Resolve returns the team a name refers to
+func (r *TeamResolver) Resolve(name string) (*models.Team, bool) {
+	if team, ok := r.byKey[NormalizeTeamName(name)]; ok {
+		return team, true
+	}
+	r.mu.Lock()
+	r.unmatched[strings.TrimSpace(name)]++
+	r.mu.Unlock()
+	return nil, false
+}
+
+// ResolveID returns the ID of the team a name refers to
+func (r *TeamResolver) ResolveID(name string) (string, bool) {
+	team, ok := r.Resolve(name)
+	if !ok {
+		return "", false
+	}
+	return team.ID, true
+}
+
+// ResolveExternal returns the team with the given ID in an external source such as "sportmonks"
+func (r *TeamResolver) ResolveExternal(source, id string) (*models.Team, bool) {
+	if team, ok := r.byExternal[source+":"+id]; ok {
+		return team, true
+	}
+	r.mu.Lock()
+	r.unmatched[source+":"+id]++
+	r.mu.Unlock()
+	return nil, false
+}
+
+// Unmatched returns every name that failed to resolve, most frequent first
+func (r *TeamResolver) Unmatched() []UnmatchedName {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]UnmatchedName, 0, len(r.unmatched))
+	for name, count := range r.unmatched {
+		names = append(names, UnmatchedName{Name: name, Count: count})
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Count != names[j].Count {
+			return names[i].Count > names[j].Count
+		}
+		return names[i].Name < names[j].Name
+	})
+	return names
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidInvite      = errors.New("invalid or expired invite")
+	ErrInvalidRole        = errors.New("invalid role change")
+	ErrUserExists         = errors.New("user already exists")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+const (
+	DefaultInviteTTL = 72 * time.Hour
+	MaxInviteTTL     = 30 * 24 * time.Hour
+)
+
+// Actor is the signed-in user making a change, as read from their token
+type Actor struct {
+	ID    string
+	Email string
+}
+
+// CreateInvite issues a single-use invite to a staff role (ADMIN if role is empty). The
+// token is only returned here; the database keeps its hash. If email is set, only that
+// address can accept the invite.
+func (s *AuthService) CreateInvite(actor Actor, email, role string, ttl time.Duration) (*models.Invite, string, error) {
+	if role == "" {
+		role = models.RoleAdmin
+	}
+	if !models.ValidRole(role) || role == models.RoleUser {
+		return nil, "", fmt.Errorf("%w: %q is not a staff role", ErrInvalidInvite, role)
+	}
+	if ttl <= 0 {
+		ttl = DefaultInviteTTL
+	}
+	if ttl > MaxInviteTTL {
+		return nil, "", fmt.Errorf("%w: invites last at most %s", ErrInvalidInvite, MaxInviteTTL)
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	invite := &models.Invite{
+		TokenHash: hashToken(token),
+		Email:     strings.TrimSpace(email),
+		Role:      role,
+		CreatedBy: actor.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.inviteRepo.CreateInvite(invite); err != nil {
+		return nil, "", err
+	}
+	log.Printf("[Auth] %s invited %s as %s until %s", actor.Email, inviteeLabel(invite.Email), invite.Role, invite.ExpiresAt.Format(time.RFC3339))
+	return invite, token, nil
+}
+
+func (s *AuthService) GetPendingInvites() ([]models.Invite, error) {
+	return s.inviteRepo.GetPendingInvites()
+}
+
+func (s *AuthService) RevokeInvite(id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	return s.inviteRepo.DeleteInvite(objID)
+}
+
+// AcceptInvite redeems an invite. A new address gets an account with the invite's role;
+// an existing user takes on the role once their password is checked, unless they are the
+// last user who can manage roles and the new role can't. The invite is checked before
+// the password, and wrong passwords count towards the login lockout, so accepting can't
+// be used to guess passwords. If the role needs two-factor authentication, an
+// MFAChallengeError comes back instead of a session.
+func (s *AuthService) AcceptInvite(token, email, password, fullName string, client Client) (*TokenPair, *models.User, error) {
+	if _, err := s.inviteRepo.GetUsableInvite(hashToken(token), email); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, ErrInvalidInvite
+		}
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.GetUserByEmail(email)
+	isNew := false
+	switch {
+	case err == nil:
+		if err := s.checkLockout(email); err != nil {
+			return nil, nil, err
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+			s.recordLoginFailure(email)
+			return nil, nil, ErrInvalidCredentials
+		}
+	case errors.Is(err, mongo.ErrNoDocuments):
+		if fullName == "" {
+			return nil, nil, fmt.Errorf("%w: fullName is required for a new account", ErrInvalidInvite)
+		}
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, err
+		}
+		user = &models.User{
+			ID:       primitive.NewObjectID(),
+			Email:    email,
+			Password: string(hashed),
+			FullName: fullName,
+		}
+		isNew = true
+	default:
+		return nil, nil, err
+	}
+
+	invite, err := s.inviteRepo.RedeemInvite(hashToken(token), email, user.ID.Hex())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil, ErrInvalidInvite
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from := user.Role
+	if !isNew && !models.RoleHas(invite.Role, models.PermManageUsers) {
+		// As with SetRole, the last user who can manage roles can't lose that permission
+		err = s.checkNotLastManager(user)
+	}
+	if err == nil {
+		user.Role = invite.Role
+		if isNew {
+			err = s.userRepo.CreateUser(user)
+		} else {
+			err = s.userRepo.UpdateRole(user.ID, user.Role)
+		}
+	}
+	if err != nil {
+		// Leave the invite for another try
+		if releaseErr := s.inviteRepo.ReleaseInvite(invite.ID); releaseErr != nil {
+			log.Printf("[Auth] Failed to release invite %s: %v", invite.ID.Hex(), releaseErr)
+		}
+		return nil, nil, err
+	}
+
+	if isNew {
+		if err := s.sendVerification(user); err != nil {
+			log.Printf("[Auth] Failed to send the verification email to %s: %v", user.Email, err)
+		}
+	}
+
+	s.recordRoleChange(&models.RoleChange{
+		UserID:  user.ID.Hex(),
+		Email:   user.Email,
+		From:    from,
+		To:      user.Role,
+		Via:     models.RoleChangeInvite,
+		ActorID: invite.CreatedBy,
+		Reason:  "invite " + invite.ID.Hex(),
+	})
+
+	tokens, err := s.signIn(user, client)
+	if err == nil && !isNew {
+		s.clearLoginFailures(email)
+	}
+	return tokens, user, err
+}
+
+// SetRole gives a user another role. Nobody can change their own role, and the last
+// user who can manage roles can't lose that permission, so there is always one left.
+func (s *AuthService) SetRole(actor Actor, userID, role, reason string) (*models.User, error) {
+	if !models.ValidRole(role) {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidRole, role)
+	}
+	if userID == actor.ID {
+		return nil, fmt.Errorf("%w: you can't change your own role", ErrInvalidRole)
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	if !models.RoleHas(role, models.PermManageUsers) {
+		if err := s.checkNotLastManager(user); err != nil {
+			return nil, err
+		}
+	}
+
+	from := user.Role
+	if err := s.userRepo.UpdateRole(user.ID, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+
+	s.recordRoleChange(&models.RoleChange{
+		UserID:     user.ID.Hex(),
+		Email:      user.Email,
+		From:       from,
+		To:         role,
+		Via:        models.RoleChangeAdmin,
+		ActorID:    actor.ID,
+		ActorEmail: actor.Email,
+		Reason:     reason,
+	})
+	return user, nil
+}
+
+// checkNotLastManager fails if user is the only one left who can manage roles
+func (s *AuthService) checkNotLastManager(user *models.User) error {
+	if !models.RoleHas(user.Role, models.PermManageUsers) {
+		return nil
+	}
+	managers, err := s.userRepo.CountByRoles(models.RolesWith(models.PermManageUsers))
+	if err != nil {
+		return err
+	}
+	if managers <= 1 {
+		return fmt.Errorf("%w: %s is the last user who can manage roles", ErrInvalidRole, user.Email)
+	}
+	return nil
+}
+
+// GetRoleChanges returns the latest entries of the role audit trail, of one user if userID is set
+func (s *AuthService) GetRoleChanges(userID string, limit int64) ([]models.RoleChange, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.roleChanges.GetRoleChanges(userID, limit)
+}
+
+// recordRoleChange writes an audit entry. The change itself has already happened, so a
+// failure is logged rather than returned.
+func (s *AuthService) recordRoleChange(change *models.RoleChange) {
+	if err := s.roleChanges.CreateRoleChange(change); err != nil {
+		log.Printf("[Auth] Failed to record role change of %s (%s -> %s): %v", change.Email, change.From, change.To, err)
+		return
+	}
+	log.Printf("[Auth] %s: %s -> %s via %s", change.Email, change.From, change.To, change.Via)
+}
+
+// hashToken is how invite tokens are stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func inviteeLabel(email string) string {
+	if email == "" {
+		return "anyone with the token"
+	}
+	return email
+}
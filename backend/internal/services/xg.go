@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+)
+
+// Base chance of scoring from each zone with a shot from open play
+var zoneXG = map[models.ShotZone]float64{
+	models.ZoneSixYardBox: 0.38,
+	models.ZoneCentralBox: 0.14,
+	models.ZoneWideBox:    0.05,
+	models.ZoneOutsideBox: 0.035,
+}
+
+// Headers are much harder to score than shots with the foot from the same spot
+const headerFactor = 0.55
+
+const (
+	penaltyXG  = 0.76
+	freeKickXG = 0.06
+)
+
+// ExpectedGoals returns the xG of a shot from its location and type
+func ExpectedGoals(zone models.ShotZone, shotType models.ShotType) (float64, error) {
+	switch shotType {
+	case models.ShotPenalty:
+		if zone != "" && zone != models.ZonePenaltySpot {
+			return 0, fmt.Errorf("a penalty must be taken from %s", models.ZonePenaltySpot)
+		}
+		return penaltyXG, nil
+	case models.ShotFreeKick:
+		if zone != models.ZoneOutsideBox {
+			return 0, fmt.Errorf("direct free kicks must be taken from %s", models.ZoneOutsideBox)
+		}
+		return freeKickXG, nil
+	case models.ShotFoot, models.ShotHeader:
+		base, ok := zoneXG[zone]
+		if !ok {
+			return 0, fmt.Errorf("invalid shot zone: %s", zone)
+		}
+		if shotType == models.ShotHeader {
+			if zone == models.ZoneOutsideBox {
+				return 0, fmt.Errorf("headers from %s are not supported", zone)
+			}
+			base *= headerFactor
+		}
+		return roundTo(base, 3), nil
+	default:
+		return 0, fmt.Errorf("invalid shot type: %s", shotType)
+	}
+}
+
+// randomShot picks a plausible shot location and type for the simulator (avg ~0.11 xG)
+func randomShot() (models.ShotZone, models.ShotType) {
+	r := rand.Float64()
+	switch {
+	case r < 0.02:
+		return models.ZonePenaltySpot, models.ShotPenalty
+	case r < 0.10:
+		if rand.Float64() < 0.3 {
+			return models.ZoneSixYardBox, models.ShotHeader
+		}
+		return models.ZoneSixYardBox, models.ShotFoot
+	case r < 0.47:
+		if rand.Float64() < 0.2 {
+			return models.ZoneCentralBox, models.ShotHeader
+		}
+		return models.ZoneCentralBox, models.ShotFoot
+	case r < 0.62:
+		return models.ZoneWideBox, models.ShotFoot
+	default:
+		if rand.Float64() < 0.08 {
+			return models.ZoneOutsideBox, models.ShotFreeKick
+		}
+		return models.ZoneOutsideBox, models.ShotFoot
+	}
+}
+
+// shotCount returns how many shots a side takes in a simulated match, around the given mean
+func shotCount(mean int) int {
+	n := mean - 4 + rand.Intn(9)
+	if n < 1 {
+		return 1
+	}
+	return n
+}
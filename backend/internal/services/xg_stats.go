@@ -0,0 +1,311 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sanat-07/English-Premier-League/backend/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TeamXG compares a team's chances with its actual goals. Only matches with recorded
+// shots are counted, so seeded results without shot data don't skew the comparison.
+type TeamXG struct {
+	TeamID       string  `json:"teamId"`
+	TeamName     string  `json:"teamName"`
+	Matches      int     `json:"matches"`
+	Shots        int     `json:"shots"`
+	Goals        int     `json:"goals"`
+	XG           float64 `json:"xg"`
+	GoalsAgainst int     `json:"goalsAgainst"`
+	XGA          float64 `json:"xga"`
+	XGD          float64 `json:"xgd"`
+	// Performance is goals minus xG: positive means finishing above expectation
+	Performance float64 `json:"performance"`
+}
+
+type PlayerXG struct {
+	PlayerID    string  `json:"playerId"`
+	PlayerName  string  `json:"playerName"`
+	TeamID      string  `json:"teamId"`
+	TeamName    string  `json:"teamName"`
+	Shots       int     `json:"shots"`
+	Goals       int     `json:"goals"`
+	XG          float64 `json:"xg"`
+	XGPerShot   float64 `json:"xgPerShot"`
+	Performance float64 `json:"performance"`
+}
+
+// XGTimelineEntry is one shot with the running xG totals of both sides after it
+type XGTimelineEntry struct {
+	Minute     int             `json:"minute"`
+	TeamID     string          `json:"teamId"`
+	PlayerID   string          `json:"playerId"`
+	PlayerName string          `json:"playerName"`
+	ShotZone   models.ShotZone `json:"shotZone"`
+	ShotType   models.ShotType `json:"shotType"`
+	XG         float64         `json:"xg"`
+	IsGoal     bool            `json:"isGoal"`
+	HomeXG     float64         `json:"homeXg"`
+	AwayXG     float64         `json:"awayXg"`
+}
+
+type MatchXG struct {
+	MatchID      string             `json:"matchId"`
+	Status       models.MatchStatus `json:"status"`
+	HomeTeamID   string             `json:"homeTeamId"`
+	HomeTeamName string             `json:"homeTeamName"`
+	AwayTeamID   string             `json:"awayTeamId"`
+	AwayTeamName string             `json:"awayTeamName"`
+	HomeScore    int                `json:"homeScore"`
+	AwayScore    int                `json:"awayScore"`
+	HomeXG       float64            `json:"homeXg"`
+	AwayXG       float64            `json:"awayXg"`
+	Timeline     []XGTimelineEntry  `json:"timeline"`
+}
+
+func (s *StatsService) teamNames() (map[string]string, error) {
+	teams, err := s.teamRepo.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string)
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+// GetTeamXG returns a season's xG for and against per team, best xG difference first;
+// an empty season means the active one
+func (s *StatsService) GetTeamXG(season string) ([]TeamXG, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.matchRepo.GetShotEvents(seasonID, "")
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.matchRepo.GetMatchesBySeason(seasonID)
+	if err != nil {
+		return nil, err
+	}
+	teamNames, err := s.teamNames()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.Match)
+	for _, m := range matches {
+		byID[m.ID] = m
+	}
+
+	table := make(map[string]*TeamXG)
+	row := func(teamID string) *TeamXG {
+		t, ok := table[teamID]
+		if !ok {
+			t = &TeamXG{TeamID: teamID, TeamName: teamNames[teamID]}
+			table[teamID] = t
+		}
+		return t
+	}
+
+	// Goals come from the scoreline of every match that has shot data
+	tracked := make(map[string]bool)
+	for _, shot := range shots {
+		match, ok := byID[shot.MatchID]
+		if !ok {
+			continue
+		}
+		if !tracked[match.ID] {
+			tracked[match.ID] = true
+			home, away := row(match.HomeTeamID), row(match.AwayTeamID)
+			home.Matches++
+			away.Matches++
+			home.Goals += match.HomeScore
+			home.GoalsAgainst += match.AwayScore
+			away.Goals += match.AwayScore
+			away.GoalsAgainst += match.HomeScore
+		}
+
+		opponent := match.AwayTeamID
+		if shot.TeamID == match.AwayTeamID {
+			opponent = match.HomeTeamID
+		}
+		row(shot.TeamID).Shots++
+		row(shot.TeamID).XG += shot.XG
+		row(opponent).XGA += shot.XG
+	}
+
+	result := make([]TeamXG, 0, len(table))
+	for _, t := range table {
+		t.XG = roundTo(t.XG, 2)
+		t.XGA = roundTo(t.XGA, 2)
+		t.XGD = roundTo(t.XG-t.XGA, 2)
+		t.Performance = roundTo(float64(t.Goals)-t.XG, 2)
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].XGD != result[j].XGD {
+			return result[i].XGD > result[j].XGD
+		}
+		return result[i].TeamName < result[j].TeamName
+	})
+	return result, nil
+}
+
+// GetPlayerXG returns the players with the highest xG in a season, optionally for a
+// single team. A limit of 0 returns every player with a recorded shot.
+func (s *StatsService) GetPlayerXG(season, teamID string, limit int) ([]PlayerXG, error) {
+	seasonID, err := ResolveSeasonID(season)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.matchRepo.GetShotEvents(seasonID, "")
+	if err != nil {
+		return nil, err
+	}
+	teamNames, err := s.teamNames()
+	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[string]*PlayerXG)
+	for _, shot := range shots {
+		if teamID != "" && shot.TeamID != teamID {
+			continue
+		}
+		p, ok := players[shot.PlayerID]
+		if !ok {
+			p = &PlayerXG{
+				PlayerID:   shot.PlayerID,
+				PlayerName: shot.PlayerName,
+				TeamID:     shot.TeamID,
+				TeamName:   teamNames[shot.TeamID],
+			}
+			players[shot.PlayerID] = p
+		}
+		p.Shots++
+		p.XG += shot.XG
+		if shot.IsGoal {
+			p.Goals++
+		}
+	}
+
+	result := make([]PlayerXG, 0, len(players))
+	for _, p := range players {
+		p.XGPerShot = roundTo(p.XG/float64(p.Shots), 3)
+		p.Performance = roundTo(float64(p.Goals)-p.XG, 2)
+		p.XG = roundTo(p.XG, 2)
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].XG != result[j].XG {
+			return result[i].XG > result[j].XG
+		}
+		return result[i].PlayerName < result[j].PlayerName
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetMatchXG returns a match's shots in order with the cumulative xG of both sides
+func (s *StatsService) GetMatchXG(matchID string) (*MatchXG, error) {
+	match, err := s.matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+	shots, err := s.matchRepo.GetShotEvents("", matchID)
+	if err != nil {
+		return nil, err
+	}
+	teamNames, err := s.teamNames()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MatchXG{
+		MatchID:      match.ID,
+		Status:       match.Status,
+		HomeTeamID:   match.HomeTeamID,
+		HomeTeamName: teamNames[match.HomeTeamID],
+		AwayTeamID:   match.AwayTeamID,
+		AwayTeamName: teamNames[match.AwayTeamID],
+		HomeScore:    match.HomeScore,
+		AwayScore:    match.AwayScore,
+		Timeline:     []XGTimelineEntry{},
+	}
+
+	homeXG, awayXG := 0.0, 0.0
+	for _, shot := range shots {
+		if shot.TeamID == match.HomeTeamID {
+			homeXG += shot.XG
+		} else {
+			awayXG += shot.XG
+		}
+		result.Timeline = append(result.Timeline, XGTimelineEntry{
+			Minute:     shot.Minute,
+			TeamID:     shot.TeamID,
+			PlayerID:   shot.PlayerID,
+			PlayerName: shot.PlayerName,
+			ShotZone:   shot.ShotZone,
+			ShotType:   shot.ShotType,
+			XG:         shot.XG,
+			IsGoal:     shot.IsGoal,
+			HomeXG:     roundTo(homeXG, 2),
+			AwayXG:     roundTo(awayXG, 2),
+		})
+	}
+	result.HomeXG = roundTo(homeXG, 2)
+	result.AwayXG = roundTo(awayXG, 2)
+	return result, nil
+}
+
+// RecordShot adds a shot to a live or finished match, with its xG taken from the shot model.
+// It only records the chance: a goal from it is still added through the goal events.
+func (s *StatsService) RecordShot(matchID, playerID string, zone models.ShotZone, shotType models.ShotType, minute int, isGoal bool) (*models.MatchEvent, error) {
+	xg, err := ExpectedGoals(zone, shotType)
+	if err != nil {
+		return nil, err
+	}
+	if shotType == models.ShotPenalty {
+		zone = models.ZonePenaltySpot
+	}
+	match, err := s.matchRepo.GetMatchByID(matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.Status != models.MatchLive && match.Status != models.MatchFinished {
+		return nil, fmt.Errorf("shots can only be recorded for LIVE or FINISHED matches (current: %s)", match.Status)
+	}
+	player, err := s.matchRepo.GetPlayerByID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+		return nil, fmt.Errorf("player %s does not play for either team", player.Name)
+	}
+
+	shot := &models.MatchEvent{
+		ID:         primitive.NewObjectID().Hex(),
+		MatchID:    matchID,
+		PlayerID:   playerID,
+		PlayerName: player.Name,
+		TeamID:     player.TeamID,
+		SeasonID:   match.SeasonID,
+		Matchday:   match.Matchday,
+		Type:       models.Shot,
+		Minute:     minute,
+		ShotZone:   zone,
+		ShotType:   shotType,
+		XG:         xg,
+		IsGoal:     isGoal,
+	}
+	if err := s.eventRepo.CreateMatchEvent(shot); err != nil {
+		return nil, err
+	}
+	return shot, nil
+}
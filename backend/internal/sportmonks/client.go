@@ -0,0 +1,304 @@
+// Package sportmonks is a client for the SportMonks football API (v3). It retries
+// failed requests with backoff, keeps under the plan's rate limit, follows pagination,
+// and can record responses to JSON files on disk and replay them later, so importers
+// can run without a network connection or an API token.
+package sportmonks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultBaseURL = "https://api.sportmonks.com/v3/football"
+
+// Mode decides where responses come from
+type Mode string
+
+const (
+	ModeLive   Mode = "live"   // Call the API
+	ModeRecord Mode = "record" // Call the API and save every response to the fixtures directory
+	ModeReplay Mode = "replay" // Serve responses from the fixtures directory only
+)
+
+var (
+	ErrNoToken   = errors.New("sportmonks: no API token (set SPORTMONKS_API_TOKEN)")
+	ErrNoFixture = errors.New("sportmonks: no recorded response")
+)
+
+// APIError is a response the API answered with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Message    string
+	Path       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("sportmonks: %s: %d %s", e.Path, e.StatusCode, e.Message)
+}
+
+// retryable reports whether the request may succeed if sent again
+func (e *APIError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+type Options struct {
+	Token             string
+	BaseURL           string // Defaults to DefaultBaseURL; point it at an httptest server to test offline
+	Mode              Mode   // Defaults to ModeLive
+	FixturesDir       string // Required for ModeRecord and ModeReplay
+	RequestsPerMinute int    // 0 means no limit
+	MaxRetries        int    // Attempts after the first; defaults to 3
+	Backoff           time.Duration
+	HTTPClient        *http.Client
+	PerPage           int // Page size for paginated endpoints; defaults to 50
+}
+
+type Client struct {
+	token       string
+	baseURL     string
+	mode        Mode
+	fixtures    *fixtureStore
+	http        *http.Client
+	maxRetries  int
+	backoff     time.Duration
+	perPage     int
+	minInterval time.Duration
+
+	mu          sync.Mutex
+	nextRequest time.Time // Earliest time the rate limit allows the next request
+}
+
+func NewClient(opts Options) (*Client, error) {
+	c := &Client{
+		token:      opts.Token,
+		baseURL:    strings.TrimRight(opts.BaseURL, "/"),
+		mode:       opts.Mode,
+		http:       opts.HTTPClient,
+		maxRetries: opts.MaxRetries,
+		backoff:    opts.Backoff,
+		perPage:    opts.PerPage,
+	}
+	if c.baseURL == "" {
+		c.baseURL = DefaultBaseURL
+	}
+	if c.mode == "" {
+		c.mode = ModeLive
+	}
+	if c.http == nil {
+		c.http = &http.Client{Timeout: 30 * time.Second}
+	}
+	if c.maxRetries == 0 {
+		c.maxRetries = 3
+	}
+	if c.backoff == 0 {
+		c.backoff = time.Second
+	}
+	if c.perPage == 0 {
+		c.perPage = 50
+	}
+	if opts.RequestsPerMinute > 0 {
+		c.minInterval = time.Minute / time.Duration(opts.RequestsPerMinute)
+	}
+
+	switch c.mode {
+	case ModeLive:
+	case ModeRecord, ModeReplay:
+		if opts.FixturesDir == "" {
+			return nil, fmt.Errorf("sportmonks: %s mode needs a fixtures directory", c.mode)
+		}
+		c.fixtures = &fixtureStore{dir: opts.FixturesDir}
+	default:
+		return nil, fmt.Errorf("sportmonks: unknown mode %q (use live, record or replay)", c.mode)
+	}
+	if c.mode != ModeReplay && c.token == "" {
+		return nil, ErrNoToken
+	}
+	return c, nil
+}
+
+// response is the envelope every v3 endpoint returns
+type response struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination *struct {
+		CurrentPage int  `json:"current_page"`
+		HasMore     bool `json:"has_more"`
+	} `json:"pagination"`
+	RateLimit *struct {
+		ResetsInSeconds int `json:"resets_in_seconds"`
+		Remaining       int `json:"remaining"`
+	} `json:"rate_limit"`
+	Message string `json:"message"`
+}
+
+// get fetches one page of path and decodes its data into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) (*response, error) {
+	body, err := c.fetch(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("sportmonks: %s: decode response: %w", path, err)
+	}
+	if out != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return nil, fmt.Errorf("sportmonks: %s: decode data: %w", path, err)
+		}
+	}
+	return &resp, nil
+}
+
+// getAll follows the pagination of path, calling page with the data of every page
+func (c *Client) getAll(ctx context.Context, path string, query url.Values, page func(data json.RawMessage) error) error {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("per_page", strconv.Itoa(c.perPage))
+
+	for n := 1; ; n++ {
+		q.Set("page", strconv.Itoa(n))
+		resp, err := c.get(ctx, path, q, nil)
+		if err != nil {
+			return err
+		}
+		if err := page(resp.Data); err != nil {
+			return err
+		}
+		if resp.Pagination == nil || !resp.Pagination.HasMore {
+			return nil
+		}
+	}
+}
+
+// fetch returns the raw body for path, from disk in replay mode and from the API otherwise
+func (c *Client) fetch(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	if c.mode == ModeReplay {
+		return c.fixtures.load(path, query)
+	}
+
+	var body []byte
+	var err error
+	for attempt := 0; ; attempt++ {
+		var wait time.Duration
+		body, wait, err = c.do(ctx, path, query)
+		if err == nil {
+			break
+		}
+		var apiErr *APIError
+		if (errors.As(err, &apiErr) && !apiErr.retryable()) || ctx.Err() != nil || attempt >= c.maxRetries {
+			return nil, err
+		}
+
+		// Exponential backoff with jitter, unless the API said how long to wait
+		if wait == 0 {
+			wait = c.backoff << attempt
+			if jitter := int64(wait) / 2; jitter > 0 {
+				wait += time.Duration(rand.Int63n(jitter))
+			}
+		}
+		log.Printf("[SportMonks] %s failed (%v), retrying in %s", path, err, wait.Round(time.Millisecond))
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.mode == ModeRecord {
+		if err := c.fixtures.save(path, query, body); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// do sends a single request. On failure it returns how long the API asked us to wait, if it said.
+func (c *Client) do(ctx context.Context, path string, query url.Values) ([]byte, time.Duration, error) {
+	if err := c.throttle(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+strings.TrimLeft(path, "/")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	// The token goes in a header so it never shows up in logged URLs
+	req.Header.Set("Authorization", c.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Path: path, Message: http.StatusText(resp.StatusCode)}
+		var envelope response
+		if json.Unmarshal(body, &envelope) == nil && envelope.Message != "" {
+			apiErr.Message = envelope.Message
+		}
+		var wait time.Duration
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(s) * time.Second
+		} else if envelope.RateLimit != nil && resp.StatusCode == http.StatusTooManyRequests {
+			wait = time.Duration(envelope.RateLimit.ResetsInSeconds) * time.Second
+		}
+		return nil, wait, apiErr
+	}
+
+	// Out of requests for this entity: hold the next request until the window resets
+	var envelope response
+	if json.Unmarshal(body, &envelope) == nil && envelope.RateLimit != nil && envelope.RateLimit.Remaining == 0 {
+		c.mu.Lock()
+		reset := time.Now().Add(time.Duration(envelope.RateLimit.ResetsInSeconds) * time.Second)
+		if reset.After(c.nextRequest) {
+			c.nextRequest = reset
+		}
+		c.mu.Unlock()
+	}
+	return body, 0, nil
+}
+
+// throttle waits until the rate limit allows another request
+func (c *Client) throttle(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	at := c.nextRequest
+	if at.Before(now) {
+		at = now
+	}
+	c.nextRequest = at.Add(c.minInterval)
+	c.mu.Unlock()
+
+	return sleep(ctx, time.Until(at))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
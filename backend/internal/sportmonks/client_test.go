@@ -0,0 +1,273 @@
+package sportmonks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testServer answers every request with handler and counts the requests
+type testServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	times    []time.Time
+}
+
+func newTestServer(t *testing.T, handler func(n int, w http.ResponseWriter, r *http.Request)) *testServer {
+	t.Helper()
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.times = append(s.times, time.Now())
+		n := len(s.requests)
+		s.mu.Unlock()
+		handler(n, w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func newTestClient(t *testing.T, opts Options) *Client {
+	t.Helper()
+	if opts.Token == "" && opts.Mode != ModeReplay {
+		opts.Token = "secret"
+	}
+	if opts.Backoff == 0 {
+		opts.Backoff = time.Millisecond
+	}
+	c, err := NewClient(opts)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+const standingsBody = `{"data": [{"participant_id": 19, "position": 1, "points": 3, "participant": {"id": 19, "name": "Arsenal"}}]}`
+
+func TestRetriesServerErrors(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if n < 3 {
+			http.Error(w, `{"message": "try again"}`, http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, standingsBody)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL})
+
+	standings, err := c.Standings(context.Background(), "25583")
+	if err != nil {
+		t.Fatalf("Standings: %v", err)
+	}
+	if len(standings) != 1 || standings[0].Participant.Name != "Arsenal" {
+		t.Errorf("got %+v", standings)
+	}
+	if srv.count() != 3 {
+		t.Errorf("got %d requests, want 3", srv.count())
+	}
+
+	r := srv.requests[0]
+	if r.Header.Get("Authorization") != "secret" || r.URL.Query().Has("api_token") {
+		t.Errorf("token should be sent in the Authorization header only, got %q and %q", r.Header.Get("Authorization"), r.URL.RawQuery)
+	}
+}
+
+func TestGivesUpAfterMaxRetries(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL, MaxRetries: 2})
+
+	_, err := c.Standings(context.Background(), "25583")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got %v, want a 503 APIError", err)
+	}
+	if srv.count() != 3 {
+		t.Errorf("got %d requests, want 3", srv.count())
+	}
+}
+
+func TestDoesNotRetryClientErrors(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "No result(s) found matching your request."}`)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL})
+
+	_, err := c.Standings(context.Background(), "1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("got %v, want a 404 APIError", err)
+	}
+	if apiErr.Message != "No result(s) found matching your request." {
+		t.Errorf("got message %q", apiErr.Message)
+	}
+	if srv.count() != 1 {
+		t.Errorf("got %d requests, want 1", srv.count())
+	}
+}
+
+func TestTooManyRequestsWaitsRetryAfter(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, standingsBody)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL})
+
+	if _, err := c.Standings(context.Background(), "25583"); err != nil {
+		t.Fatalf("Standings: %v", err)
+	}
+	if srv.count() != 2 {
+		t.Fatalf("got %d requests, want 2", srv.count())
+	}
+	if gap := srv.times[1].Sub(srv.times[0]); gap < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", gap)
+	}
+}
+
+func TestTooManyRequestsWaitsForReset(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		if n == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"message": "Too many requests", "rate_limit": {"resets_in_seconds": 1, "remaining": 0}}`)
+			return
+		}
+		fmt.Fprint(w, standingsBody)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL})
+
+	if _, err := c.Standings(context.Background(), "25583"); err != nil {
+		t.Fatalf("Standings: %v", err)
+	}
+	if gap := srv.times[1].Sub(srv.times[0]); gap < time.Second {
+		t.Errorf("retried after %s, want at least the 1s reset", gap)
+	}
+}
+
+func TestHoldsRequestsWhenNoneRemain(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": [], "rate_limit": {"resets_in_seconds": 1, "remaining": 0}}`)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Standings(context.Background(), "25583"); err != nil {
+			t.Fatalf("Standings: %v", err)
+		}
+	}
+	if gap := srv.times[1].Sub(srv.times[0]); gap < time.Second {
+		t.Errorf("second request after %s, want it held until the reset", gap)
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	srv := newTestServer(t, func(n int, w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, Options{BaseURL: srv.URL, Backoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Standings(ctx, "25583"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// fixturePages serves fixtures 1..total, perPage at a time
+func fixturePages(total int) func(n int, w http.ResponseWriter, r *http.Request) {
+	return func(n int, w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		first := (page-1)*perPage + 1
+		last := min(first+perPage-1, total)
+		fmt.Fprint(w, `{"data": [`)
+		for id := first; id <= last; id++ {
+			if id > first {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id": %d, "round": {"name": "%d"}}`, id, id)
+		}
+		fmt.Fprintf(w, `], "pagination": {"current_page": %d, "has_more": %t}}`, page, last < total)
+	}
+}
+
+func TestFixturesFollowsPagination(t *testing.T) {
+	srv := newTestServer(t, fixturePages(7))
+	c := newTestClient(t, Options{BaseURL: srv.URL, PerPage: 3})
+
+	fixtures, err := c.Fixtures(context.Background(), "25583")
+	if err != nil {
+		t.Fatalf("Fixtures: %v", err)
+	}
+	if len(fixtures) != 7 {
+		t.Fatalf("got %d fixtures, want 7", len(fixtures))
+	}
+	for i, f := range fixtures {
+		if f.ID != i+1 || f.Matchday() != i+1 {
+			t.Errorf("fixture %d is %+v", i, f)
+		}
+	}
+	if srv.count() != 3 {
+		t.Errorf("got %d requests, want 3", srv.count())
+	}
+	q := srv.requests[0].URL.Query()
+	if q.Get("filters") != "fixtureSeasons:25583" || q.Get("include") != "participants;scores;state;round" {
+		t.Errorf("unexpected query %s", srv.requests[0].URL.RawQuery)
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	srv := newTestServer(t, fixturePages(5))
+	recorder := newTestClient(t, Options{BaseURL: srv.URL, Mode: ModeRecord, FixturesDir: dir, PerPage: 2})
+	recorded, err := recorder.Fixtures(context.Background(), "25583")
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	replayer := newTestClient(t, Options{Mode: ModeReplay, FixturesDir: dir, PerPage: 2})
+	replayed, err := replayer.Fixtures(context.Background(), "25583")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if len(replayed) != 5 || len(recorded) != 5 {
+		t.Errorf("recorded %d fixtures and replayed %d, want 5", len(recorded), len(replayed))
+	}
+	if srv.count() != 3 {
+		t.Errorf("replay should not call the API; got %d requests, want 3", srv.count())
+	}
+
+	if _, err := replayer.Standings(context.Background(), "25583"); !errors.Is(err, ErrNoFixture) {
+		t.Errorf("got %v, want ErrNoFixture", err)
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	if _, err := NewClient(Options{}); !errors.Is(err, ErrNoToken) {
+		t.Errorf("live mode without a token: got %v, want ErrNoToken", err)
+	}
+	if _, err := NewClient(Options{Mode: ModeReplay}); err == nil {
+		t.Error("replay mode without a fixtures directory should fail")
+	}
+	if _, err := NewClient(Options{Mode: "offline", Token: "x"}); err == nil {
+		t.Error("an unknown mode should fail")
+	}
+}
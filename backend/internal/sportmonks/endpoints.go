@@ -0,0 +1,52 @@
+package sportmonks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Squad returns a team's squad with each player's nationality and position
+func (c *Client) Squad(ctx context.Context, teamID, seasonID string) ([]SquadEntry, error) {
+	query := url.Values{"include": {"player.nationality;player.position"}}
+	if seasonID != "" {
+		query.Set("filters", "playerstatisticSeasons:"+seasonID)
+	}
+	var squad []SquadEntry
+	if _, err := c.get(ctx, "squads/teams/"+teamID, query, &squad); err != nil {
+		return nil, err
+	}
+	return squad, nil
+}
+
+// Standings returns the league table of a season
+func (c *Client) Standings(ctx context.Context, seasonID string) ([]Standing, error) {
+	query := url.Values{"include": {"participant;details"}}
+	var standings []Standing
+	if _, err := c.get(ctx, "standings/seasons/"+seasonID, query, &standings); err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
+
+// Fixtures returns every fixture of a season, following the pagination
+func (c *Client) Fixtures(ctx context.Context, seasonID string) ([]Fixture, error) {
+	query := url.Values{
+		"include": {"participants;scores;state;round"},
+		"filters": {"fixtureSeasons:" + seasonID},
+	}
+	var fixtures []Fixture
+	err := c.getAll(ctx, "fixtures", query, func(data json.RawMessage) error {
+		var page []Fixture
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("sportmonks: fixtures: decode data: %w", err)
+		}
+		fixtures = append(fixtures, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
@@ -0,0 +1,64 @@
+package sportmonks
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fixtureStore keeps one JSON file per request, named after its path and query, e.g.
+// "squads_teams_9__filters=playerstatisticSeasons_25583&include=player.nationality;player.position.json"
+type fixtureStore struct {
+	dir string
+}
+
+func (s *fixtureStore) load(path string, query url.Values) ([]byte, error) {
+	file := s.file(path, query)
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w for %s (expected %s)", ErrNoFixture, path, file)
+	}
+	return data, err
+}
+
+func (s *fixtureStore) save(path string, query url.Values, body []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.file(path, query), body, 0o644)
+}
+
+// file names the fixture for a request. The first page of a paginated request shares
+// its file with the unpaginated request, so a hand-made single-page fixture replays too.
+func (s *fixtureStore) file(path string, query url.Values) string {
+	name := strings.ReplaceAll(strings.Trim(path, "/"), "/", "_")
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if k == "api_token" || k == "per_page" || (k == "page" && query.Get(k) == "1") {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, k+"="+strings.Join(query[k], ","))
+	}
+	if len(params) > 0 {
+		name += "__" + strings.Join(params, "&")
+	}
+
+	// Keep the name safe on every filesystem
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	return filepath.Join(s.dir, name+".json")
+}
@@ -0,0 +1,182 @@
+package sportmonks
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type Participant struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ShortCode string `json:"short_code"`
+	ImagePath string `json:"image_path"`
+	VenueID   int    `json:"venue_id"`
+	Meta      struct {
+		Location string `json:"location"` // "home" or "away" on a fixture
+	} `json:"meta"`
+}
+
+type Nationality struct {
+	Name      string `json:"name"`
+	ImagePath string `json:"image_path"` // Country flag
+	ISO2      string `json:"iso2"`
+	ISO3      string `json:"iso3"`
+}
+
+type Position struct {
+	Name string `json:"name"`
+}
+
+type Player struct {
+	ID          int         `json:"id"`
+	CommonName  string      `json:"common_name"`
+	FirstName   string      `json:"firstname"`
+	LastName    string      `json:"lastname"`
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	ImagePath   string      `json:"image_path"`
+	Height      Number      `json:"height"`
+	Weight      Number      `json:"weight"`
+	DateOfBirth string      `json:"date_of_birth"`
+	Nationality Nationality `json:"nationality"`
+	Position    Position    `json:"position"`
+}
+
+// SquadEntry is a player's place in a team's squad
+type SquadEntry struct {
+	PlayerID     int    `json:"player_id"`
+	TeamID       int    `json:"team_id"`
+	Captain      bool   `json:"captain"`
+	JerseyNumber int    `json:"jersey_number"`
+	Player       Player `json:"player"`
+}
+
+// Standing detail type IDs
+const (
+	TypePlayed       = 129
+	TypeWon          = 130
+	TypeDraw         = 131
+	TypeLost         = 132
+	TypeGoalsFor     = 133
+	TypeGoalsAgainst = 134
+	TypeGoalDiff     = 179
+)
+
+type Standing struct {
+	ParticipantID int         `json:"participant_id"`
+	SeasonID      int         `json:"season_id"`
+	Position      int         `json:"position"`
+	Points        int         `json:"points"`
+	Participant   Participant `json:"participant"`
+	Details       []struct {
+		TypeID int `json:"type_id"`
+		Value  int `json:"value"`
+	} `json:"details"`
+}
+
+// Detail returns the value of one of the Type* details, 0 if it is missing
+func (s *Standing) Detail(typeID int) int {
+	for _, d := range s.Details {
+		if d.TypeID == typeID {
+			return d.Value
+		}
+	}
+	return 0
+}
+
+// Score type description of the full-time score
+const ScoreCurrent = "CURRENT"
+
+// Fixture states, by developer name
+const (
+	StateNotStarted = "NS"
+	StateFullTime   = "FT"
+	StateAfterET    = "AET"
+	StatePenalties  = "FT_PEN"
+	StatePostponed  = "POSTPONED"
+	StateCancelled  = "CANCELLED"
+	StateAbandoned  = "ABANDONED"
+)
+
+type Fixture struct {
+	ID           int           `json:"id"`
+	SeasonID     int           `json:"season_id"`
+	Name         string        `json:"name"`
+	StartingAt   string        `json:"starting_at"` // "2025-08-16 14:00:00", UTC
+	Participants []Participant `json:"participants"`
+	Scores       []struct {
+		ParticipantID int    `json:"participant_id"`
+		Description   string `json:"description"`
+		Score         struct {
+			Goals       int    `json:"goals"`
+			Participant string `json:"participant"` // "home" or "away"
+		} `json:"score"`
+	} `json:"scores"`
+	State struct {
+		DeveloperName string `json:"developer_name"`
+	} `json:"state"`
+	Round struct {
+		Name string `json:"name"` // The matchday, e.g. "12"
+	} `json:"round"`
+}
+
+// Kickoff parses StartingAt
+func (f *Fixture) Kickoff() (time.Time, error) {
+	return time.Parse("2006-01-02 15:04:05", f.StartingAt)
+}
+
+// Matchday returns the round number, 0 if the round isn't a number
+func (f *Fixture) Matchday() int {
+	n, _ := strconv.Atoi(f.Round.Name)
+	return n
+}
+
+// Team returns the participant playing at location ("home" or "away")
+func (f *Fixture) Team(location string) (Participant, bool) {
+	for _, p := range f.Participants {
+		if p.Meta.Location == location {
+			return p, true
+		}
+	}
+	return Participant{}, false
+}
+
+// Goals returns the full-time score for location ("home" or "away")
+func (f *Fixture) Goals(location string) int {
+	for _, s := range f.Scores {
+		if s.Description == ScoreCurrent && s.Score.Participant == location {
+			return s.Score.Goals
+		}
+	}
+	return 0
+}
+
+// Finished reports whether the fixture has a final result
+func (f *Fixture) Finished() bool {
+	switch f.State.DeveloperName {
+	case StateFullTime, StateAfterET, StatePenalties:
+		return true
+	}
+	return false
+}
+
+// Number is an integer the API sometimes sends as a string, and sometimes as null
+type Number int
+
+func (n *Number) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case float64:
+		*n = Number(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		*n = Number(i)
+	default:
+		*n = 0
+	}
+	return nil
+}
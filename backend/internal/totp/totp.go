@@ -0,0 +1,90 @@
+// Package totp implements time-based one-time passwords (RFC 6238) the way
+// authenticator apps use them: HMAC-SHA1, 6 digits and 30-second steps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Digits = 6
+	Period = 30 * time.Second
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a random 160-bit secret, base32-encoded for authenticator apps
+func GenerateSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Step is the number of the time step t falls in
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code is the code of secret for the time step t falls in
+func Code(secret string, t time.Time) (string, error) {
+	return generate(secret, Step(t))
+}
+
+func generate(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("totp: invalid secret: %w", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	n := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", n%1000000), nil
+}
+
+// Validate checks code against the step t falls in and skew steps either side, for
+// clocks that drift. It returns the step the code belongs to, so callers can refuse
+// a code that has been used before.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+	now := Step(t)
+	for i := -int64(skew); i <= int64(skew); i++ {
+		want, err := generate(secret, now+i)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(want), []byte(code)) {
+			return now + i, true
+		}
+	}
+	return 0, false
+}
+
+// URI is the otpauth:// URI authenticator apps read from a QR code
+func URI(issuer, account, secret string) string {
+	q := url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(Digits)},
+		"period":    {fmt.Sprint(int(Period / time.Second))},
+	}
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
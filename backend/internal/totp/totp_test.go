@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890"
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238(t *testing.T) {
+	// RFC 6238 appendix B gives 8 digits; authenticator apps show the last 6
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Code: %v", err)
+		}
+		if want := tt.want[len(tt.want)-Digits:]; got != want {
+			t.Errorf("at %d got %s, want %s", tt.unix, got, want)
+		}
+	}
+
+	// Apps show the secret in either case
+	if got, _ := Code(strings.ToLower(rfcSecret), time.Unix(59, 0)); got != "287082" {
+		t.Errorf("lower-case secret gave %s, want 287082", got)
+	}
+	if _, err := Code("not base32!", time.Now()); err == nil {
+		t.Error("an invalid secret should fail")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	tests := []struct {
+		name     string
+		code     string
+		skew     int
+		wantStep int64
+		ok       bool
+	}{
+		{"current step", "050471", 1, Step(now), true},
+		{"previous step within the skew", "081804", 1, Step(now) - 1, true},
+		{"previous step without skew", "081804", 0, 0, false},
+		{"wrong code", "123456", 1, 0, false},
+		{"8 digits", "14050471", 1, 0, false},
+		{"empty", "", 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(rfcSecret, tt.code, now, tt.skew)
+			if ok != tt.ok || step != tt.wantStep {
+				t.Errorf("got step %d, %t; want %d, %t", step, ok, tt.wantStep, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	b, _ := GenerateSecret()
+	if len(a) != 32 || a == b {
+		t.Errorf("got %q and %q, want two different 160-bit secrets", a, b)
+	}
+	now := time.Now()
+	code, err := Code(a, now)
+	if err != nil {
+		t.Fatalf("Code: %v", err)
+	}
+	if _, ok := Validate(a, code, now, 0); !ok {
+		t.Error("a fresh code didn't validate")
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("EPL", "fan@example.com", rfcSecret)
+	want := "otpauth://totp/EPL:fan@example.com?algorithm=SHA1&digits=6&issuer=EPL&period=30&secret=" + rfcSecret
+	if got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+}
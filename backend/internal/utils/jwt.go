@@ -8,13 +8,14 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// GenerateToken issues a short-lived access token. Sessions last longer through refresh tokens.
 func GenerateToken(userID string, email string, role string) (string, error) {
 	cfg := config.LoadConfig()
 	claims := jwt.MapClaims{
 		"sub":   userID,
 		"email": email,
 		"role":  role,
-		"exp":   time.Now().Add(time.Hour * 72).Unix(),
+		"exp":   time.Now().Add(AccessTokenTTL(cfg)).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -30,3 +31,42 @@ func ValidateToken(tokenString string) (*jwt.Token, error) {
 		return []byte(cfg.JWTSecret), nil
 	})
 }
+
+// MFATokenTTL is how long a user has to enter their two-factor code after the password
+const MFATokenTTL = 5 * time.Minute
+
+// GenerateMFAToken issues the token of a sign-in waiting for its second factor. It is
+// signed with a different key, so it is never accepted as an access token.
+func GenerateMFAToken(userID string) (string, error) {
+	cfg := config.LoadConfig()
+	claims := jwt.MapClaims{
+		"sub": userID,
+		"exp": time.Now().Add(MFATokenTTL).Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(mfaKey(cfg))
+}
+
+// ValidateMFAToken returns the user ID of a valid MFA token
+func ValidateMFAToken(tokenString string) (string, error) {
+	cfg := config.LoadConfig()
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return mfaKey(cfg), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return "", errors.New("invalid or expired MFA token")
+	}
+	return token.Claims.GetSubject()
+}
+
+func mfaKey(cfg *config.Config) []byte {
+	return []byte(cfg.JWTSecret + ":mfa")
+}
+
+// AccessTokenTTL is how long an access token is valid
+func AccessTokenTTL(cfg *config.Config) time.Duration {
+	if cfg.AccessTokenTTL <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(cfg.AccessTokenTTL) * time.Minute
+}
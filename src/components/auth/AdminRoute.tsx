@@ -1,5 +1,6 @@
 import { Navigate, useLocation } from "react-router-dom";
 import { useEffect, useState } from "react";
+import { isStaff } from "@/lib/utils";
 
 export default function AdminRoute({ children }: { children: React.ReactNode }) {
     const [user, setUser] = useState<any>(null);
@@ -18,7 +19,7 @@ export default function AdminRoute({ children }: { children: React.ReactNode })
         return <div className="min-h-screen bg-[#37003c] flex items-center justify-center text-[#37003c] font-black italic">Loading...</div>;
     }
 
-    if (!user || user.role !== "ADMIN") {
+    if (!isStaff(user)) {
         return <Navigate to="/auth" state={{ from: location }} replace />;
     }
 
@@ -2,6 +2,8 @@ import { Link, useLocation, useNavigate } from "react-router-dom";
 import { Trophy, Calendar, Users, Briefcase, Menu, X, TrendingUp, LogOut, User as UserIcon } from "lucide-react";
 import { useState, useEffect } from "react";
 import { motion, AnimatePresence } from "framer-motion";
+import { apiService } from "@/lib/api";
+import { isStaff } from "@/lib/utils";
 
 const navItems = [
     { name: "Standings", href: "/standings", icon: Trophy },
@@ -40,8 +42,8 @@ export function Navbar() {
         return () => window.removeEventListener("auth-change", checkAuth);
     }, []);
 
-    const handleLogout = () => {
-        localStorage.removeItem("epl_current_user");
+    const handleLogout = async () => {
+        await apiService.logout();
         setUser(null);
         navigate("/");
     };
@@ -64,7 +66,7 @@ export function Navbar() {
                     <div className="hidden md:flex items-center gap-1">
                         {(isAdmin ? adminItems : navItems).map((item) => {
                             // Check if admin item and user is not admin
-                            if (item.href.startsWith("/admin") && user?.role !== "ADMIN") return null;
+                            if (item.href.startsWith("/admin") && !isStaff(user)) return null;
 
                             const Icon = item.icon;
                             const isActive = pathname === item.href;
@@ -19,6 +19,70 @@ api.interceptors.request.use((config) => {
     return config;
 });
 
+export interface SessionTokens {
+    token: string;
+    refreshToken: string;
+}
+
+// A sign-in either completes, or needs a TOTP code exchanged for the session with the mfaToken
+export type SignInResponse =
+    | (SessionTokens & { user: any; mfaRequired?: false })
+    | { mfaRequired: true; mfaToken: string; expiresIn: number; enrollRequired: boolean };
+
+export interface MFAEnrolment {
+    secret: string;
+    uri: string; // otpauth:// URI for authenticator apps
+}
+
+// Keeps the tokens of a sign-in, or a refresh, for later requests
+export function storeSession(tokens: SessionTokens) {
+    localStorage.setItem('epl_token', tokens.token);
+    localStorage.setItem('epl_refresh_token', tokens.refreshToken);
+}
+
+export function clearSession() {
+    localStorage.removeItem('epl_token');
+    localStorage.removeItem('epl_refresh_token');
+    localStorage.removeItem('epl_current_user');
+}
+
+// Access tokens are short-lived: on a 401, trade the refresh token for a new pair and
+// retry once. Concurrent requests share one refresh, since each refresh token works once.
+let refreshing: Promise<string> | null = null;
+
+function refreshSession(): Promise<string> {
+    if (!refreshing) {
+        const refreshToken = localStorage.getItem('epl_refresh_token');
+        refreshing = (refreshToken
+            ? axios.post<SessionTokens>('/api/auth/refresh', { refreshToken }).then((res) => {
+                storeSession(res.data);
+                return res.data.token;
+            })
+            : Promise.reject(new Error('No session'))
+        ).finally(() => {
+            refreshing = null;
+        });
+    }
+    return refreshing;
+}
+
+api.interceptors.response.use(undefined, async (error) => {
+    const original = error.config;
+    if (error.response?.status !== 401 || !original || original._retried || original.url?.startsWith('/auth/')) {
+        return Promise.reject(error);
+    }
+    original._retried = true;
+    try {
+        const token = await refreshSession();
+        original.headers.Authorization = `Bearer ${token}`;
+        return api(original);
+    } catch {
+        clearSession();
+        window.dispatchEvent(new Event('auth-change'));
+        return Promise.reject(error);
+    }
+});
+
 // Response types from backend
 export interface StandingWithTeam extends Standing {
     team: Team;
@@ -87,16 +151,53 @@ export const apiService = {
     },
 
     // Authentication
-    async login(data: { email: string; password: string }): Promise<{ token: string; user: any }> {
-        const response = await api.post<{ token: string; user: any }>('/auth/login', data);
+    async login(data: { email: string; password: string }): Promise<SignInResponse> {
+        const response = await api.post<SignInResponse>('/auth/login', data);
         return response.data;
     },
 
-    async register(data: { email: string; password: string; fullName: string; role: string }): Promise<{ token: string; message: string }> {
-        const response = await api.post<{ token: string; message: string }>('/auth/register', data);
+    // Second step of a sign-in with two-factor authentication: a TOTP or recovery code
+    async verifyMFA(data: { mfaToken: string; code: string }): Promise<SessionTokens & { user: any }> {
+        const response = await api.post<SessionTokens & { user: any }>('/auth/mfa/verify', data);
         return response.data;
     },
 
+    // For a sign-in whose role requires two-factor authentication the user hasn't set up
+    async enrollPendingMFA(mfaToken: string): Promise<MFAEnrolment> {
+        const response = await api.post<MFAEnrolment>('/auth/mfa/enroll', { mfaToken });
+        return response.data;
+    },
+
+    async confirmPendingMFA(data: { mfaToken: string; code: string }): Promise<SessionTokens & { user: any; recoveryCodes: string[] }> {
+        const response = await api.post<SessionTokens & { user: any; recoveryCodes: string[] }>('/auth/mfa/confirm', data);
+        return response.data;
+    },
+
+    async register(data: { email: string; password: string; fullName: string }): Promise<SessionTokens & { message: string }> {
+        const response = await api.post<SessionTokens & { message: string }>('/auth/register', data);
+        return response.data;
+    },
+
+    async getMe(): Promise<{ user: any; permissions: string[] }> {
+        const response = await api.get<{ user: any; permissions: string[] }>('/user/me');
+        return response.data;
+    },
+
+    // Whether to offer single sign-on, and the provider's name for the button
+    async getOIDCConfig(): Promise<{ enabled: boolean; name: string }> {
+        const response = await api.get<{ enabled: boolean; name: string }>('/auth/oidc/config');
+        return response.data;
+    },
+
+    // Ends the session on the server too, so its refresh token can't be used again
+    async logout(): Promise<void> {
+        const refreshToken = localStorage.getItem('epl_refresh_token');
+        clearSession();
+        if (refreshToken) {
+            await api.post('/auth/logout', { refreshToken }).catch(() => undefined);
+        }
+    },
+
     // Favorites
     async getFavorites(): Promise<{ teams: string[]; players: string[] }> {
         const response = await api.get<{ teams: string[]; players: string[] }>('/user/favorites');
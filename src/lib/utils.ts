@@ -35,3 +35,7 @@ export const getFlagClass = (iso2: string | undefined | null) => {
     if (code === 'wls') return 'gb-wls';
     return code;
 };
+
+// Staff roles can open the admin area; the API checks what each one may change
+export const isStaff = (user: { role?: string } | null | undefined) =>
+    !!user?.role && user.role !== "USER";
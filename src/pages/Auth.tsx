@@ -1,7 +1,7 @@
-import { useState, Suspense } from "react";
-import { ArrowRight, Lock, Mail, User, AlertCircle } from "lucide-react";
+import { useState, useEffect, Suspense } from "react";
+import { ArrowRight, Lock, Mail, User, AlertCircle, KeyRound } from "lucide-react";
 import { useSearchParams, useNavigate } from "react-router-dom";
-import { apiService } from "@/lib/api";
+import { apiService, storeSession, SessionTokens, MFAEnrolment } from "@/lib/api";
 
 function AuthForm() {
     const [searchParams] = useSearchParams();
@@ -11,12 +11,66 @@ function AuthForm() {
     const [isLoading, setIsLoading] = useState(false);
     const [error, setError] = useState<string | null>(null);
 
+    const [sso, setSso] = useState<{ enabled: boolean; name: string } | null>(null);
+
+    // Second step of a sign-in with two-factor authentication
+    const [mfa, setMfa] = useState<{ token: string; enroll: boolean; redirect: string } | null>(null);
+    const [enrolment, setEnrolment] = useState<MFAEnrolment | null>(null);
+    const [mfaCode, setMfaCode] = useState("");
+    const [recovery, setRecovery] = useState<{ codes: string[]; finish: () => void } | null>(null);
+
+    const completeSignIn = (tokens: SessionTokens, user: any, redirect = "/") => {
+        storeSession(tokens);
+        localStorage.setItem("epl_current_user", JSON.stringify(user));
+
+        // Dispatch custom event for Navbar to update
+        window.dispatchEvent(new Event("auth-change"));
+        navigate(redirect);
+    };
+
+    // A role that requires two-factor authentication enrols the user before signing in
+    const startMFA = async (token: string, enroll: boolean, redirect = "/") => {
+        setMfa({ token, enroll, redirect });
+        if (enroll) {
+            setEnrolment(await apiService.enrollPendingMFA(token));
+        }
+    };
+
+    // Single sign-on ends back here with the session, an MFA token or an error in the URL fragment
+    useEffect(() => {
+        const params = new URLSearchParams(window.location.hash.slice(1));
+        if (window.location.hash) {
+            window.history.replaceState(null, "", window.location.pathname + window.location.search);
+        }
+        const token = params.get("token");
+        const refreshToken = params.get("refreshToken");
+        const mfaToken = params.get("mfaToken");
+        const redirect = params.get("redirect") || "/";
+        if (params.get("error")) {
+            setError(params.get("error"));
+        } else if (mfaToken) {
+            startMFA(mfaToken, params.get("enrollRequired") === "true", redirect)
+                .catch((err) => setError(err.response?.data?.error || "Sign-in failed"));
+        } else if (token && refreshToken) {
+            setIsLoading(true);
+            storeSession({ token, refreshToken });
+            apiService.getMe()
+                .then(({ user }) => completeSignIn({ token, refreshToken }, user, redirect))
+                .catch((err) => {
+                    setError(err.response?.data?.error || "Sign-in failed");
+                    setIsLoading(false);
+                });
+        }
+
+        apiService.getOIDCConfig().then(setSso).catch(() => setSso(null));
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, []);
+
     // Form states
     const [formData, setFormData] = useState({
         email: "",
         password: "",
-        fullName: "",
-        role: "USER" as "USER" | "ADMIN"
+        fullName: ""
     });
 
     const handleChange = (e: React.ChangeEvent<HTMLInputElement>) => {
@@ -39,35 +93,19 @@ function AuthForm() {
                 await apiService.register({
                     email: formData.email,
                     password: formData.password,
-                    fullName: formData.fullName,
-                    role: formData.role
+                    fullName: formData.fullName
                 });
+            }
 
-                // Auto-login to get user details
-                const loginResponse = await apiService.login({
-                    email: formData.email,
-                    password: formData.password
-                });
-
-                localStorage.setItem("epl_token", loginResponse.token);
-                localStorage.setItem("epl_current_user", JSON.stringify(loginResponse.user));
-
-                // Dispatch custom event for Navbar to update
-                window.dispatchEvent(new Event("auth-change"));
-                navigate("/");
+            // Log in, which also gets the user details after registering
+            const response = await apiService.login({
+                email: formData.email,
+                password: formData.password
+            });
+            if (response.mfaRequired) {
+                await startMFA(response.mfaToken, response.enrollRequired);
             } else {
-                // Login
-                const response = await apiService.login({
-                    email: formData.email,
-                    password: formData.password
-                });
-
-                localStorage.setItem("epl_token", response.token);
-                localStorage.setItem("epl_current_user", JSON.stringify(response.user));
-
-                // Dispatch custom event for Navbar to update
-                window.dispatchEvent(new Event("auth-change"));
-                navigate("/");
+                completeSignIn(response, response.user);
             }
         } catch (err: any) {
             console.error("Auth Error:", err);
@@ -78,6 +116,31 @@ function AuthForm() {
         }
     };
 
+    const handleMfaSubmit = async (e: React.FormEvent) => {
+        e.preventDefault();
+        if (!mfa) return;
+        setIsLoading(true);
+        setError(null);
+
+        try {
+            if (mfa.enroll) {
+                const response = await apiService.confirmPendingMFA({ mfaToken: mfa.token, code: mfaCode });
+                // Recovery codes are shown only this once
+                setRecovery({
+                    codes: response.recoveryCodes,
+                    finish: () => completeSignIn(response, response.user, mfa.redirect),
+                });
+            } else {
+                const response = await apiService.verifyMFA({ mfaToken: mfa.token, code: mfaCode });
+                completeSignIn(response, response.user, mfa.redirect);
+            }
+        } catch (err: any) {
+            setError(err.response?.data?.error || err.message || "Authentication failed");
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
     return (
         <div className="w-full max-w-md bg-white/5 backdrop-blur-xl p-10 rounded-[3rem] shadow-2xl relative border border-white/10 overflow-hidden group/card transition-all hover:border-[#00ff85]/30">
             <div className="absolute inset-0 bg-gradient-to-br from-[#00ff85]/5 to-transparent opacity-0 group-hover/card:opacity-100 transition-opacity" />
@@ -105,73 +168,135 @@ function AuthForm() {
                 </div>
             )}
 
-            <form onSubmit={handleSubmit} className="space-y-6 relative z-10">
-                {mode === "register" && (
+            {recovery ? (
+                <div className="space-y-6 relative z-10">
+                    <p className="text-white/60 font-outfit text-sm">
+                        Two-factor authentication is on. Keep these recovery codes somewhere safe: each one signs you in once if you lose your authenticator app. They won't be shown again.
+                    </p>
+                    <ul className="grid grid-cols-2 gap-2 font-mono text-white text-sm">
+                        {recovery.codes.map(code => <li key={code} className="px-3 py-2 rounded-xl bg-white/10">{code}</li>)}
+                    </ul>
+                    <button type="button" onClick={recovery.finish} className="w-full py-5 bg-[#00ff85] text-[#37003c] font-outfit font-black uppercase tracking-[0.3em] rounded-2xl hover:bg-white hover:scale-[1.02] active:scale-[0.98] transition-all shadow-[0_0_40px_rgba(0,255,133,0.15)] flex items-center justify-center gap-3 disabled:opacity-50">
+                        Continue <ArrowRight className="w-5 h-5" />
+                    </button>
+                </div>
+            ) : mfa ? (
+                <form onSubmit={handleMfaSubmit} className="space-y-6 relative z-10">
+                    {mfa.enroll ? (
+                        <div className="space-y-3 text-white/60 font-outfit text-sm">
+                            <p>Your role requires two-factor authentication. Add this key to your authenticator app, then enter the code it shows.</p>
+                            {enrolment && (
+                                <>
+                                    <p className="font-mono text-white break-all select-all">{enrolment.secret}</p>
+                                    <a href={enrolment.uri} className="text-[#00ff85] underline">Open in authenticator app</a>
+                                </>
+                            )}
+                        </div>
+                    ) : (
+                        <p className="text-white/60 font-outfit text-sm">
+                            Enter the code from your authenticator app, or one of your recovery codes.
+                        </p>
+                    )}
                     <div className="relative group/field">
-                        <User className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
+                        <KeyRound className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
                         <input
                             type="text"
-                            name="fullName"
-                            placeholder="Full Name"
-                            value={formData.fullName}
-                            onChange={handleChange}
+                            name="code"
+                            inputMode="numeric"
+                            autoComplete="one-time-code"
+                            placeholder="Code"
+                            value={mfaCode}
+                            onChange={(e) => { setMfaCode(e.target.value); if (error) setError(null); }}
                             required
+                            autoFocus
                             className="w-full pl-14 pr-6 py-4.5 rounded-2xl border border-white/10 bg-white/10 focus:outline-none focus:ring-2 focus:ring-[#00ff85]/20 focus:border-[#00ff85] transition-all font-outfit font-bold text-white placeholder:text-white/20 selection:bg-[#00ff85]/30"
                         />
                     </div>
-                )}
-                <div className="relative group/field">
-                    <Mail className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
-                    <input
-                        type="email"
-                        name="email"
-                        placeholder="Email Address"
-                        value={formData.email}
-                        onChange={handleChange}
-                        required
-                        className="w-full pl-14 pr-6 py-4.5 rounded-2xl border border-white/10 bg-white/10 focus:outline-none focus:ring-2 focus:ring-[#00ff85]/20 focus:border-[#00ff85] transition-all font-outfit font-bold text-white placeholder:text-white/20 selection:bg-[#00ff85]/30"
-                    />
-                </div>
-                <div className="relative group/field">
-                    <Lock className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
-                    <input
-                        type="password"
-                        name="password"
-                        placeholder="Password"
-                        value={formData.password}
-                        onChange={handleChange}
-                        required
-                        className="w-full pl-14 pr-6 py-4.5 rounded-2xl border border-white/10 bg-white/10 focus:outline-none focus:ring-2 focus:ring-[#00ff85]/20 focus:border-[#00ff85] transition-all font-outfit font-bold text-white placeholder:text-white/20 selection:bg-[#00ff85]/30"
-                    />
-                </div>
+                    <button type="submit" disabled={isLoading} className="w-full py-5 bg-[#00ff85] text-[#37003c] font-outfit font-black uppercase tracking-[0.3em] rounded-2xl hover:bg-white hover:scale-[1.02] active:scale-[0.98] transition-all shadow-[0_0_40px_rgba(0,255,133,0.15)] flex items-center justify-center gap-3 disabled:opacity-50">
+                        {isLoading ? "Processing..." : "Verify"}
+                        {!isLoading && <ArrowRight className="w-5 h-5" />}
+                    </button>
+                </form>
+            ) : (
+                <>
+                    <form onSubmit={handleSubmit} className="space-y-6 relative z-10">
+                        {mode === "register" && (
+                            <div className="relative group/field">
+                                <User className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
+                                <input
+                                    type="text"
+                                    name="fullName"
+                                    placeholder="Full Name"
+                                    value={formData.fullName}
+                                    onChange={handleChange}
+                                    required
+                                    className="w-full pl-14 pr-6 py-4.5 rounded-2xl border border-white/10 bg-white/10 focus:outline-none focus:ring-2 focus:ring-[#00ff85]/20 focus:border-[#00ff85] transition-all font-outfit font-bold text-white placeholder:text-white/20 selection:bg-[#00ff85]/30"
+                                />
+                            </div>
+                        )}
+                        <div className="relative group/field">
+                            <Mail className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
+                            <input
+                                type="email"
+                                name="email"
+                                placeholder="Email Address"
+                                value={formData.email}
+                                onChange={handleChange}
+                                required
+                                className="w-full pl-14 pr-6 py-4.5 rounded-2xl border border-white/10 bg-white/10 focus:outline-none focus:ring-2 focus:ring-[#00ff85]/20 focus:border-[#00ff85] transition-all font-outfit font-bold text-white placeholder:text-white/20 selection:bg-[#00ff85]/30"
+                            />
+                        </div>
+                        <div className="relative group/field">
+                            <Lock className="absolute left-5 top-1/2 -translate-y-1/2 w-5 h-5 text-white/20 group-focus-within/field:text-[#00ff85] transition-colors" />
+                            <input
+                                type="password"
+                                name="password"
+                                placeholder="Password"
+                                value={formData.password}
+                                onChange={handleChange}
+                                required
+                                className="w-full pl-14 pr-6 py-4.5 rounded-2xl border border-white/10 bg-white/10 focus:outline-none focus:ring-2 focus:ring-[#00ff85]/20 focus:border-[#00ff85] transition-all font-outfit font-bold text-white placeholder:text-white/20 selection:bg-[#00ff85]/30"
+                            />
+                        </div>
 
 
 
-                <button
-                    type="submit"
-                    disabled={isLoading}
-                    className="w-full py-5 bg-[#00ff85] text-[#37003c] font-outfit font-black uppercase tracking-[0.3em] rounded-2xl hover:bg-white hover:scale-[1.02] active:scale-[0.98] transition-all shadow-[0_0_40px_rgba(0,255,133,0.15)] flex items-center justify-center gap-3 disabled:opacity-50"
-                >
-                    {isLoading ? "Processing..." : (mode === "login" ? "Sign In" : "Register")}
-                    {!isLoading && <ArrowRight className="w-5 h-5" />}
-                </button>
-            </form>
-
-            <div className="mt-12 text-center relative z-10">
-                <p className="text-white/30 text-[10px] font-outfit font-black uppercase tracking-[0.3em]">
-                    {mode === "login" ? "New to the platform?" : "Already a member?"}
-                    <button
-                        type="button"
-                        onClick={() => {
-                            setMode(mode === "login" ? "register" : "login");
-                            setError(null);
-                        }}
-                        className="ml-3 text-[#ff005a] hover:text-[#00ff85] transition-colors font-black"
-                    >
-                        {mode === "login" ? "Create Account" : "Sign In"}
-                    </button>
-                </p>
-            </div>
+                        <button
+                            type="submit"
+                            disabled={isLoading}
+                            className="w-full py-5 bg-[#00ff85] text-[#37003c] font-outfit font-black uppercase tracking-[0.3em] rounded-2xl hover:bg-white hover:scale-[1.02] active:scale-[0.98] transition-all shadow-[0_0_40px_rgba(0,255,133,0.15)] flex items-center justify-center gap-3 disabled:opacity-50"
+                        >
+                            {isLoading ? "Processing..." : (mode === "login" ? "Sign In" : "Register")}
+                            {!isLoading && <ArrowRight className="w-5 h-5" />}
+                        </button>
+                    </form>
+
+                    {sso?.enabled && (
+                        <a
+                            href="/api/auth/oidc/login"
+                            className="mt-6 w-full py-5 border border-white/10 text-white font-outfit font-black uppercase tracking-[0.3em] rounded-2xl hover:border-[#00ff85] hover:text-[#00ff85] transition-all flex items-center justify-center gap-3 relative z-10"
+                        >
+                            Continue with {sso.name}
+                        </a>
+                    )}
+
+                    <div className="mt-12 text-center relative z-10">
+                        <p className="text-white/30 text-[10px] font-outfit font-black uppercase tracking-[0.3em]">
+                            {mode === "login" ? "New to the platform?" : "Already a member?"}
+                            <button
+                                type="button"
+                                onClick={() => {
+                                    setMode(mode === "login" ? "register" : "login");
+                                    setError(null);
+                                }}
+                                className="ml-3 text-[#ff005a] hover:text-[#00ff85] transition-colors font-black"
+                            >
+                                {mode === "login" ? "Create Account" : "Sign In"}
+                            </button>
+                        </p>
+                    </div>
+                </>
+            )}
         </div>
     );
 }
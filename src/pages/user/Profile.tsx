@@ -53,9 +53,8 @@ export default function ProfilePage() {
         loadData();
     }, [favTeams, favPlayers]);
 
-    const handleLogout = () => {
-        localStorage.removeItem("epl_token");
-        localStorage.removeItem("epl_current_user");
+    const handleLogout = async () => {
+        await apiService.logout();
         window.dispatchEvent(new Event("auth-change"));
         navigate("/");
     };
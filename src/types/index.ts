@@ -81,6 +81,7 @@ export interface MatchEvent {
     playerId: string;
     type: EventType;
     minute: number;
+    replacedPlayerId?: string; // Substitutions: the player going off
 }
 
 export interface GoalEvent {
@@ -103,5 +104,7 @@ export interface GoalEvent {
 export interface User {
     id: string;
     username: string;
-    role: 'GUEST' | 'ADMIN';
+    role: Role;
 }
+
+export type Role = 'USER' | 'MATCH_OFFICIAL' | 'DATA_EDITOR' | 'MODERATOR' | 'ADMIN' | 'SUPERADMIN';